@@ -2,11 +2,13 @@ package flowapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -21,7 +23,13 @@ type Client struct {
 	httpClient *http.Client
 	apiVersion string
 
-	// Authentication
+	// mu guards accessToken, refreshToken, and tokenExpiry, which
+	// GetAccessToken reads and refreshes from multiple goroutines when one
+	// Client is shared across callers (e.g. replication.Scheduler running
+	// several policies against the same site concurrently).
+	mu sync.Mutex
+
+	// Authentication. Access only while holding mu.
 	accessToken  string
 	refreshToken string
 	tokenExpiry  time.Time
@@ -29,6 +37,14 @@ type Client struct {
 	// Script credentials for re-authentication
 	scriptName string
 	scriptKey  string
+
+	// tokenStore persists accessToken/refreshToken/tokenExpiry so they
+	// survive process restarts; defaults to an in-memory store.
+	tokenStore TokenStore
+
+	// defaultTimeout, when set, bounds every request made by this client
+	// that isn't already running against a context with an earlier deadline.
+	defaultTimeout time.Duration
 }
 
 // Config holds the configuration for creating a new Client
@@ -47,10 +63,27 @@ type Config struct {
 
 	// HTTPClient allows you to provide a custom HTTP client
 	HTTPClient *http.Client
+
+	// DefaultTimeout bounds every request made by the client when the
+	// caller's context has no deadline of its own. Zero means no default
+	// deadline is applied beyond whatever the context or HTTPClient impose.
+	DefaultTimeout time.Duration
+
+	// Middlewares wraps every outgoing request's RoundTripper, letting
+	// callers layer in retry, rate limiting, logging, metrics, or their
+	// own cross-cutting concerns. Applied in order: the first middleware
+	// is outermost. See WithRetry, WithRateLimit, WithLogger, and
+	// WithMetrics.
+	Middlewares []Middleware
+
+	// TokenStore persists the client's access/refresh tokens. Defaults to
+	// an in-memory store; pass a FileTokenStore to survive restarts or
+	// share tokens across processes.
+	TokenStore TokenStore
 }
 
 // NewClient creates a new Flow API client
-func NewClient(config Config) (*Client, error) {
+func NewClient(ctx context.Context, config Config) (*Client, error) {
 	if config.SiteURL == "" {
 		return nil, fmt.Errorf("site URL is required")
 	}
@@ -73,22 +106,70 @@ func NewClient(config Config) (*Client, error) {
 		}
 	}
 
+	if len(config.Middlewares) > 0 {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		wrapped := *httpClient
+		wrapped.Transport = chain(base, config.Middlewares)
+		httpClient = &wrapped
+	}
+
+	tokenStore := config.TokenStore
+	if tokenStore == nil {
+		tokenStore = NewMemoryTokenStore()
+	}
+
 	client := &Client{
-		baseURL:    config.SiteURL,
-		apiVersion: apiVersion,
-		httpClient: httpClient,
-		scriptName: config.ScriptName,
-		scriptKey:  config.ScriptKey,
+		baseURL:        config.SiteURL,
+		apiVersion:     apiVersion,
+		httpClient:     httpClient,
+		scriptName:     config.ScriptName,
+		scriptKey:      config.ScriptKey,
+		tokenStore:     tokenStore,
+		defaultTimeout: config.DefaultTimeout,
 	}
 
-	// Authenticate immediately
-	if err := client.authenticate(); err != nil {
-		return nil, fmt.Errorf("initial authentication failed: %w", err)
+	// Reuse a token persisted by a previous process if it's still valid;
+	// otherwise fall through to a fresh client_credentials authentication.
+	client.mu.Lock()
+	if token, ok, err := tokenStore.Load(); err == nil && ok && time.Now().Before(token.Expiry) {
+		client.accessToken = token.AccessToken
+		client.refreshToken = token.RefreshToken
+		client.tokenExpiry = token.Expiry
+		client.mu.Unlock()
+	} else {
+		authErr := client.authenticate(ctx)
+		client.mu.Unlock()
+		if authErr != nil {
+			return nil, fmt.Errorf("initial authentication failed: %w", authErr)
+		}
 	}
 
 	return client, nil
 }
 
+// SetDefaultTimeout sets the deadline applied to requests made by this
+// client when the caller's context doesn't already carry one. Pass 0 to
+// disable it.
+func (c *Client) SetDefaultTimeout(d time.Duration) {
+	c.defaultTimeout = d
+}
+
+// withTimeout builds a context.WithDeadline from c.defaultTimeout when the
+// given context has no deadline of its own, so long-running batch jobs can
+// still be bounded without every call site having to remember to do it.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.defaultTimeout)
+}
+
 // TokenResponse represents the response from the token endpoint
 type TokenResponse struct {
 	TokenType    string `json:"token_type"`
@@ -97,8 +178,13 @@ type TokenResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-// authenticate obtains an access token using client credentials
-func (c *Client) authenticate() error {
+// authenticate obtains an access token using client credentials. Callers
+// must hold c.mu; it is only ever invoked from NewClient and
+// GetAccessToken, which take the lock themselves.
+func (c *Client) authenticate(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
 	authURL := fmt.Sprintf("%s/api/%s/auth/access_token", c.baseURL, c.apiVersion)
 
 	// Prepare form data
@@ -107,7 +193,7 @@ func (c *Client) authenticate() error {
 	data.Set("client_id", c.scriptName)
 	data.Set("client_secret", c.scriptKey)
 
-	req, err := http.NewRequest("POST", authURL, bytes.NewBufferString(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", authURL, bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -135,19 +221,89 @@ func (c *Client) authenticate() error {
 		return fmt.Errorf("failed to parse token response: %w", err)
 	}
 
+	return c.storeToken(tokenResp)
+}
+
+// refreshAccessToken exchanges the current refresh token for a new access
+// token via the refresh_token grant instead of a full client_credentials
+// re-authentication; the script credentials are still sent alongside the
+// refresh token. Callers should fall back to authenticate on
+// error, since an expired or revoked refresh token (401/invalid_grant)
+// leaves no other way to recover. Callers must hold c.mu; it is only ever
+// invoked from GetAccessToken, which takes the lock itself.
+func (c *Client) refreshAccessToken(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	authURL := fmt.Sprintf("%s/api/%s/auth/access_token", c.baseURL, c.apiVersion)
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", c.refreshToken)
+	data.Set("client_id", c.scriptName)
+	data.Set("client_secret", c.scriptKey)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", authURL, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("refresh failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp TokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	return c.storeToken(tokenResp)
+}
+
+// storeToken updates the client's in-memory token fields and persists them
+// to the configured TokenStore. Callers must hold c.mu.
+func (c *Client) storeToken(tokenResp TokenResponse) error {
 	c.accessToken = tokenResp.AccessToken
 	c.refreshToken = tokenResp.RefreshToken
 	c.tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 
-	return nil
+	return c.tokenStore.Save(Token{
+		AccessToken:  c.accessToken,
+		RefreshToken: c.refreshToken,
+		Expiry:       c.tokenExpiry,
+	})
 }
 
-// GetAccessToken returns the current access token
-// It will automatically re-authenticate if the token has expired
-func (c *Client) GetAccessToken() (string, error) {
+// GetAccessToken returns the current access token. It will automatically
+// refresh the token if it has expired, using the refresh_token grant when
+// a refresh token is available and falling back to a full
+// client_credentials re-authentication if the refresh fails.
+func (c *Client) GetAccessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Check if token is expired or about to expire (with 60 second buffer)
 	if time.Now().Add(60 * time.Second).After(c.tokenExpiry) {
-		if err := c.authenticate(); err != nil {
+		if c.refreshToken != "" {
+			if err := c.refreshAccessToken(ctx); err == nil {
+				return c.accessToken, nil
+			}
+		}
+		if err := c.authenticate(ctx); err != nil {
 			return "", err
 		}
 	}
@@ -157,5 +313,56 @@ func (c *Client) GetAccessToken() (string, error) {
 
 // IsAuthenticated checks if the client has a valid access token
 func (c *Client) IsAuthenticated() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.accessToken != "" && time.Now().Before(c.tokenExpiry)
 }
+
+// Revoke invalidates the client's current access and refresh tokens on the
+// Flow site and clears them locally (including from the TokenStore),
+// leaving the client unauthenticated. Subsequent calls will need a fresh
+// Client or a call to GetAccessToken, which re-authenticates from scratch
+// since the refresh token has also been revoked.
+func (c *Client) Revoke(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	revokeURL := fmt.Sprintf("%s/api/%s/auth/access_token/revoke", c.baseURL, c.apiVersion)
+
+	data := url.Values{}
+	data.Set("token_type_hint", "refresh_token")
+	data.Set("token", c.refreshToken)
+	data.Set("client_id", c.scriptName)
+	data.Set("client_secret", c.scriptKey)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", revokeURL, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("revoke failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	c.accessToken = ""
+	c.refreshToken = ""
+	c.tokenExpiry = time.Time{}
+
+	return c.tokenStore.Clear()
+}
@@ -0,0 +1,406 @@
+// Command flowapi-gen generates strongly-typed Go packages for Flow entity
+// types from the site's live schema, the way ent generates a Client and
+// per-entity packages from a schema definition. Run it against a real site
+// at build time:
+//
+//	go run ./cmd/flowapi-gen -site "$FLOW_SITE_URL" -script "$FLOW_SCRIPT_NAME" -key "$FLOW_SCRIPT_KEY" \
+//	    -entity shots -entity tasks -entity human_users -out ./gen
+//
+// Each -entity flag produces one package under -out named after the Go
+// identifier derived from the entity type (shots -> shot, human_users ->
+// humanuser), matching the hand-seeded packages already checked into
+// flowapi/gen for the common Flow entity types.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jonaskluger/flow-api-go"
+)
+
+type entityFlag []string
+
+func (e *entityFlag) String() string { return strings.Join(*e, ",") }
+
+func (e *entityFlag) Set(v string) error {
+	*e = append(*e, v)
+	return nil
+}
+
+func main() {
+	var (
+		site       = flag.String("site", "", "Flow site URL")
+		scriptName = flag.String("script", "", "API script name")
+		scriptKey  = flag.String("key", "", "API script key")
+		outDir     = flag.String("out", "./gen", "output directory for generated packages")
+		pkgRoot    = flag.String("pkg-root", "github.com/jonaskluger/flow-api-go", "Go import path of the module, used to import the entity runtime package")
+		entities   entityFlag
+	)
+	flag.Var(&entities, "entity", "Flow entity type to generate a package for (may be repeated)")
+	flag.Parse()
+
+	if *site == "" || *scriptName == "" || *scriptKey == "" {
+		log.Fatal("-site, -script, and -key are required")
+	}
+	if len(entities) == 0 {
+		log.Fatal("at least one -entity is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := flowapi.NewClient(ctx, flowapi.Config{
+		SiteURL:    *site,
+		ScriptName: *scriptName,
+		ScriptKey:  *scriptKey,
+	})
+	if err != nil {
+		log.Fatalf("connect to %s: %v", *site, err)
+	}
+
+	for _, et := range entities {
+		schema, err := client.GetEntitySchema(ctx, et)
+		if err != nil {
+			log.Fatalf("fetch schema for %s: %v", et, err)
+		}
+
+		pkg := packageName(et)
+		dir := filepath.Join(*outDir, pkg)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			log.Fatalf("create %s: %v", dir, err)
+		}
+
+		fields := genFields(schema)
+
+		data := templateData{
+			PackageName: pkg,
+			GoName:      goName(et),
+			EntityType:  et,
+			PkgRoot:     *pkgRoot,
+			Fields:      fields,
+			Predicates:  genPredicates(fields, schema),
+			NeedsTime:   needsTime(fields),
+		}
+
+		out := filepath.Join(dir, pkg+".go")
+		if err := renderFile(out, data); err != nil {
+			log.Fatalf("render %s: %v", out, err)
+		}
+		fmt.Printf("wrote %s\n", out)
+	}
+}
+
+// genField is the template-ready description of one generated struct field.
+type genField struct {
+	JSONName string // raw Flow field name, e.g. "sg_status_list"
+	GoName   string // exported Go identifier, e.g. "StatusList"
+	GoType   string // e.g. "string", "*entity.Ref"
+	DataType string // raw Flow data_type, e.g. "status_list"
+}
+
+// genFields converts a schema response into a sorted, template-ready field
+// list. Sorting keeps generated output byte-stable across reruns.
+func genFields(schema flowapi.EntitySchema) []genField {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]genField, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, genField{
+			JSONName: name,
+			GoName:   goFieldName(name),
+			GoType:   goType(schema[name].DataType.Value),
+			DataType: schema[name].DataType.Value,
+		})
+	}
+	return fields
+}
+
+// needsTime reports whether any field decodes into time.Time, so the
+// template knows whether to import "time".
+func needsTime(fields []genField) bool {
+	for _, f := range fields {
+		if f.GoType == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
+// packageName derives a Go package name from a Flow entity type, e.g.
+// "human_users" -> "humanuser", "shots" -> "shot".
+func packageName(entityType string) string {
+	singular := strings.TrimSuffix(entityType, "s")
+	return strings.ReplaceAll(singular, "_", "")
+}
+
+// goName derives the exported struct name for an entity type, e.g.
+// "human_users" -> "HumanUser".
+func goName(entityType string) string {
+	singular := strings.TrimSuffix(entityType, "s")
+	return goFieldName(singular)
+}
+
+// goFieldName turns a Flow snake_case field name into an exported Go
+// identifier, e.g. "sg_status_list" -> "StatusList", "task_assignees" ->
+// "TaskAssignees". The "sg_" site-custom-field prefix is dropped since it
+// carries no meaning in generated Go.
+func goFieldName(name string) string {
+	name = strings.TrimPrefix(name, "sg_")
+	parts := strings.Split(name, "_")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// goType maps a Flow field data_type to the Go type used in the generated
+// struct. Relationship fields decode through entity.Ref.
+func goType(dataType string) string {
+	switch dataType {
+	case "number", "duration", "percent":
+		return "int"
+	case "float":
+		return "float64"
+	case "checkbox":
+		return "bool"
+	case "date", "date_time":
+		return "time.Time"
+	case "entity":
+		return "*entity.Ref"
+	case "multi_entity":
+		return "[]entity.Ref"
+	default: // text, status_list, list, url, ...
+		return "string"
+	}
+}
+
+// genPredicate is the template-ready description of one generated predicate
+// builder function, e.g. "ProjectEQ" or "StatusIn".
+type genPredicate struct {
+	Doc    string // doc comment body, without the leading "// "
+	Name   string // exported function name, e.g. "ProjectEQ"
+	Params string // parameter list, e.g. "v string" or "values ...int"
+	Body   string // function body, indented as if already inside the function
+}
+
+// genPredicates builds the predicate functions for every field, chosen by
+// its Flow data_type so filter arrays are constructed with the right
+// operator and value shape (EQ, In, Between, Contains) instead of as raw
+// []interface{}.
+func genPredicates(fields []genField, schema flowapi.EntitySchema) []genPredicate {
+	var out []genPredicate
+	for _, f := range fields {
+		field := "Field" + f.GoName
+		switch f.DataType {
+		case "entity", "multi_entity":
+			if t := validType(schema[f.JSONName]); t != "" {
+				out = append(out, genPredicate{
+					Doc:    fmt.Sprintf("%sEQ matches entities linked to the given %s ID.", f.GoName, t),
+					Name:   f.GoName + "EQ",
+					Params: "id int",
+					Body:   fmt.Sprintf("return entity.EQ(%s, map[string]interface{}{\"type\": %q, \"id\": id})", field, t),
+				})
+			} else {
+				out = append(out, genPredicate{
+					Doc:    fmt.Sprintf("%sEQ matches entities whose %s matches the given ref.", f.GoName, f.JSONName),
+					Name:   f.GoName + "EQ",
+					Params: "ref entity.Ref",
+					Body:   fmt.Sprintf("return entity.EQ(%s, map[string]interface{}{\"type\": ref.Type, \"id\": ref.ID})", field),
+				})
+			}
+		case "date", "date_time":
+			out = append(out, genPredicate{
+				Doc:    fmt.Sprintf("%sBetween matches entities whose %s falls between low and high, inclusive.", f.GoName, f.JSONName),
+				Name:   f.GoName + "Between",
+				Params: "low, high time.Time",
+				Body:   fmt.Sprintf("return entity.Between(%s, low, high)", field),
+			})
+		case "number", "duration", "percent":
+			out = append(out,
+				genPredicate{
+					Doc:    fmt.Sprintf("%sEQ matches entities with the exact given %s.", f.GoName, f.JSONName),
+					Name:   f.GoName + "EQ",
+					Params: "v int",
+					Body:   fmt.Sprintf("return entity.EQ(%s, v)", field),
+				},
+				genPredicate{
+					Doc:    fmt.Sprintf("%sIn matches entities whose %s is one of the given values.", f.GoName, f.JSONName),
+					Name:   f.GoName + "In",
+					Params: "values ...int",
+					Body:   fmt.Sprintf("vs := make([]interface{}, len(values))\n\tfor i, v := range values {\n\t\tvs[i] = v\n\t}\n\treturn entity.In(%s, vs)", field),
+				},
+			)
+		case "float":
+			out = append(out, genPredicate{
+				Doc:    fmt.Sprintf("%sEQ matches entities with the exact given %s.", f.GoName, f.JSONName),
+				Name:   f.GoName + "EQ",
+				Params: "v float64",
+				Body:   fmt.Sprintf("return entity.EQ(%s, v)", field),
+			})
+		case "checkbox":
+			out = append(out, genPredicate{
+				Doc:    fmt.Sprintf("%sEQ matches entities whose %s equals v.", f.GoName, f.JSONName),
+				Name:   f.GoName + "EQ",
+				Params: "v bool",
+				Body:   fmt.Sprintf("return entity.EQ(%s, v)", field),
+			})
+		case "status_list", "list":
+			out = append(out,
+				genPredicate{
+					Doc:    fmt.Sprintf("%sEQ matches entities with the exact given %s.", f.GoName, f.JSONName),
+					Name:   f.GoName + "EQ",
+					Params: "v string",
+					Body:   fmt.Sprintf("return entity.EQ(%s, v)", field),
+				},
+				genPredicate{
+					Doc:    fmt.Sprintf("%sIn matches entities whose %s is one of the given values.", f.GoName, f.JSONName),
+					Name:   f.GoName + "In",
+					Params: "values ...string",
+					Body:   fmt.Sprintf("vs := make([]interface{}, len(values))\n\tfor i, v := range values {\n\t\tvs[i] = v\n\t}\n\treturn entity.In(%s, vs)", field),
+				},
+			)
+		default: // text, url, ...
+			out = append(out,
+				genPredicate{
+					Doc:    fmt.Sprintf("%sEQ matches entities with the exact given %s.", f.GoName, f.JSONName),
+					Name:   f.GoName + "EQ",
+					Params: "v string",
+					Body:   fmt.Sprintf("return entity.EQ(%s, v)", field),
+				},
+				genPredicate{
+					Doc:    fmt.Sprintf("%sContains matches entities whose %s contains the given substring.", f.GoName, f.JSONName),
+					Name:   f.GoName + "Contains",
+					Params: "substr string",
+					Body:   fmt.Sprintf("return entity.Contains(%s, substr)", field),
+				},
+			)
+		}
+	}
+	return out
+}
+
+// validType extracts the single Flow entity type name a relationship
+// field's schema restricts it to (e.g. "Shot"), if the schema declares
+// exactly one. Fields that can point at more than one type, or that don't
+// declare valid_types at all, fall back to a generic entity.Ref-based
+// predicate instead of guessing.
+func validType(fs flowapi.FieldSchema) string {
+	raw, ok := fs.Properties["valid_types"]
+	if !ok {
+		return ""
+	}
+	wrapper, ok := raw.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	values, ok := wrapper["value"].([]interface{})
+	if !ok || len(values) != 1 {
+		return ""
+	}
+	t, _ := values[0].(string)
+	return t
+}
+
+type templateData struct {
+	PackageName string
+	GoName      string
+	EntityType  string
+	PkgRoot     string
+	Fields      []genField
+	Predicates  []genPredicate
+	NeedsTime   bool
+}
+
+var fileTemplate = template.Must(template.New("entity").Parse(`// Code generated by flowapi-gen from the {{.GoName}} schema. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"context"
+{{- if .NeedsTime}}
+	"time"
+{{- end}}
+
+	"{{.PkgRoot}}"
+	"{{.PkgRoot}}/gen/entity"
+)
+
+// entityType is the Flow entity type this package wraps.
+const entityType = "{{.EntityType}}"
+
+// Field name constants, matching the schema field names exactly so they can
+// be passed straight through to FindEntities' fields parameter.
+const (
+{{- range .Fields}}
+	Field{{.GoName}} = "{{.JSONName}}"
+{{- end}}
+)
+
+// {{.GoName}} is the typed representation of a {{.GoName}} entity.
+type {{.GoName}} struct {
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}} ` + "`json:\"{{.JSONName}}\"`" + `
+{{- end}}
+}
+{{range .Predicates}}
+// {{.Doc}}
+func {{.Name}}({{.Params}}) entity.Predicate {
+	{{.Body}}
+}
+{{end}}
+// {{.GoName}}Query builds a fluent query over {{.EntityType}}.
+type {{.GoName}}Query struct {
+	b *entity.Builder
+}
+
+// Query starts a fluent query over {{.EntityType}}.
+func Query(c *flowapi.Client) *{{.GoName}}Query {
+	return &{{.GoName}}Query{b: entity.NewBuilder(c, entityType)}
+}
+
+// Where appends predicates to the query, ANDed together.
+func (q *{{.GoName}}Query) Where(predicates ...entity.Predicate) *{{.GoName}}Query {
+	q.b.Where(predicates...)
+	return q
+}
+
+// Fields restricts the response to the given field names.
+func (q *{{.GoName}}Query) Fields(fields ...string) *{{.GoName}}Query {
+	q.b.Fields(fields...)
+	return q
+}
+
+// All executes the query and decodes the results into typed {{.GoName}}s.
+func (q *{{.GoName}}Query) All(ctx context.Context) ([]*{{.GoName}}, error) {
+	raw, err := q.b.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entity.DecodeAll[{{.GoName}}](raw)
+}
+`))
+
+func renderFile(path string, data templateData) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return fileTemplate.Execute(f, data)
+}
@@ -0,0 +1,162 @@
+// Command flowapi-replicate runs flowapi/replication policies loaded from
+// a YAML config, either once (for a cron-driven invocation) or as a
+// long-running daemon that schedules each policy on its own cron
+// expression.
+//
+//	flowapi-replicate -config replicate.yaml -once
+//	flowapi-replicate -config replicate.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jonaskluger/flow-api-go"
+	"github.com/jonaskluger/flow-api-go/replication"
+)
+
+// siteConfig is the YAML shape for one Flow site's credentials.
+type siteConfig struct {
+	SiteURL    string `yaml:"site_url"`
+	ScriptName string `yaml:"script_name"`
+	ScriptKey  string `yaml:"script_key"`
+}
+
+// policyConfig is the YAML shape for one replication.Policy, referencing
+// sites by name instead of embedding credentials per policy.
+type policyConfig struct {
+	Name       string            `yaml:"name"`
+	Source     string            `yaml:"source"`
+	Target     string            `yaml:"target"`
+	EntityType string            `yaml:"entity_type"`
+	Filters    interface{}       `yaml:"filters"`
+	Fields     []string          `yaml:"fields"`
+	FieldMap   map[string]string `yaml:"field_map"`
+	Cron       string            `yaml:"cron"`
+	Enabled    bool              `yaml:"enabled"`
+	Conflict   string            `yaml:"conflict"`
+	DryRun     bool              `yaml:"dry_run"`
+}
+
+// fileConfig is the top-level YAML config shape.
+type fileConfig struct {
+	Sites       map[string]siteConfig `yaml:"sites"`
+	MappingFile string                `yaml:"mapping_file"`
+	Policies    []policyConfig        `yaml:"policies"`
+}
+
+func main() {
+	var (
+		configPath = flag.String("config", "replicate.yaml", "path to the replication policy YAML config")
+		once       = flag.Bool("once", false, "run every enabled policy a single time and exit, instead of running as a daemon")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	sites, err := buildClients(ctx, cfg.Sites)
+	if err != nil {
+		log.Fatalf("connect to sites: %v", err)
+	}
+
+	mappingFile := cfg.MappingFile
+	if mappingFile == "" {
+		mappingFile = "flowapi-replicate-mapping.json"
+	}
+	mapping, err := replication.NewFileMapping(mappingFile)
+	if err != nil {
+		log.Fatalf("load mapping file: %v", err)
+	}
+
+	scheduler := replication.NewScheduler(mapping, log.Default())
+	for _, pc := range cfg.Policies {
+		policy, err := buildPolicy(pc, sites)
+		if err != nil {
+			log.Fatalf("build policy %q: %v", pc.Name, err)
+		}
+		if err := scheduler.Add(policy); err != nil {
+			log.Fatalf("add policy %q: %v", pc.Name, err)
+		}
+	}
+
+	if *once {
+		if err := scheduler.RunOnce(ctx); err != nil {
+			log.Fatalf("run policies: %v", err)
+		}
+		return
+	}
+
+	log.Printf("flowapi-replicate: running %d polic(ies) as a daemon", len(cfg.Policies))
+	scheduler.Start()
+	<-ctx.Done()
+	scheduler.Stop()
+}
+
+func loadConfig(path string) (fileConfig, error) {
+	var cfg fileConfig
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// buildClients connects to every site referenced in the config up front,
+// so a credentials typo fails fast instead of mid-replication.
+func buildClients(ctx context.Context, sites map[string]siteConfig) (map[string]*flowapi.Client, error) {
+	clients := make(map[string]*flowapi.Client, len(sites))
+	for name, sc := range sites {
+		client, err := flowapi.NewClient(ctx, flowapi.Config{
+			SiteURL:    sc.SiteURL,
+			ScriptName: sc.ScriptName,
+			ScriptKey:  sc.ScriptKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("site %q: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return clients, nil
+}
+
+func buildPolicy(pc policyConfig, sites map[string]*flowapi.Client) (*replication.Policy, error) {
+	source, ok := sites[pc.Source]
+	if !ok {
+		return nil, fmt.Errorf("unknown source site %q", pc.Source)
+	}
+	target, ok := sites[pc.Target]
+	if !ok {
+		return nil, fmt.Errorf("unknown target site %q", pc.Target)
+	}
+
+	return &replication.Policy{
+		Name:       pc.Name,
+		Source:     source,
+		Target:     target,
+		EntityType: pc.EntityType,
+		Filters:    pc.Filters,
+		Fields:     pc.Fields,
+		FieldMap:   pc.FieldMap,
+		Cron:       pc.Cron,
+		Enabled:    pc.Enabled,
+		Conflict:   replication.ConflictPolicy(pc.Conflict),
+		DryRun:     pc.DryRun,
+	}, nil
+}
@@ -2,11 +2,14 @@ package flowapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -15,11 +18,28 @@ import (
 // Entity represents a generic Flow entity
 type Entity map[string]interface{}
 
-// FindEntities searches for entities of a given type with optional filters
-func (c *Client) FindEntities(entityType string, filters interface{}, fields []string) ([]Entity, error) {
-	token, err := c.GetAccessToken()
+// FindEntities searches for entities of a given type with optional
+// filters. By default it returns whatever page size the site applies;
+// pass WithPageSize/WithPageNumber, WithCursor, or WithSort to page
+// through larger result sets instead of relying on Client.Iterate to do
+// it for you.
+func (c *Client) FindEntities(ctx context.Context, entityType string, filters interface{}, fields []string, opts ...FindOption) ([]Entity, error) {
+	entities, _, err := c.findEntitiesPage(ctx, entityType, filters, fields, opts...)
+	return entities, err
+}
+
+// findEntitiesPage is FindEntities plus the pagination metadata
+// (nextCursor, hasMore) Client.Iterate needs to keep paging without
+// exposing that bookkeeping to ordinary callers.
+func (c *Client) findEntitiesPage(ctx context.Context, entityType string, filters interface{}, fields []string, opts ...FindOption) ([]Entity, pageInfo, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	options := resolveFindOptions(opts)
+
+	token, err := c.GetAccessToken(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
+		return nil, pageInfo{}, fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Build request body
@@ -33,18 +53,36 @@ func (c *Client) FindEntities(entityType string, filters interface{}, fields []s
 
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, pageInfo{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Construct URL with fields parameter
-	url := fmt.Sprintf("%s/api/%s/entity/%s/_search", c.baseURL, c.apiVersion, entityType)
+	// Construct URL with fields, sort, and pagination parameters
+	reqURL := fmt.Sprintf("%s/api/%s/entity/%s/_search", c.baseURL, c.apiVersion, entityType)
+
+	query := url.Values{}
 	if len(fields) > 0 {
-		url += "?fields=" + strings.Join(fields, ",")
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if len(options.sort) > 0 {
+		query.Set("sort", strings.Join(options.sort, ","))
+	}
+	if options.cursor != "" {
+		query.Set("page[cursor]", options.cursor)
+	} else {
+		if options.pageSize > 0 {
+			query.Set("page[size]", strconv.Itoa(options.pageSize))
+		}
+		if options.pageNumber > 0 {
+			query.Set("page[number]", strconv.Itoa(options.pageNumber))
+		}
+	}
+	if encoded := query.Encode(); encoded != "" {
+		reqURL += "?" + encoded
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyJSON))
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewBuffer(bodyJSON))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, pageInfo{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -53,17 +91,17 @@ func (c *Client) FindEntities(entityType string, filters interface{}, fields []s
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, pageInfo{}, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, pageInfo{}, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, pageInfo{}, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
 	}
 
 	var result struct {
@@ -73,10 +111,13 @@ func (c *Client) FindEntities(entityType string, filters interface{}, fields []s
 			Attributes    map[string]interface{} `json:"attributes"`
 			Relationships map[string]interface{} `json:"relationships"`
 		} `json:"data"`
+		Links struct {
+			Next string `json:"next"`
+		} `json:"links"`
 	}
 
 	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, pageInfo{}, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Convert to Entity slice
@@ -97,23 +138,36 @@ func (c *Client) FindEntities(entityType string, filters interface{}, fields []s
 		entities[i] = entity
 	}
 
-	return entities, nil
+	info := pageInfo{nextCursor: nextCursorFromLink(result.Links.Next)}
+	if options.pageSize > 0 {
+		// A full page means there's likely more; the iterator confirms
+		// this by simply requesting the next page and seeing if it's
+		// empty, so this is a cheap (if imperfect) hint.
+		info.hasMore = len(entities) >= options.pageSize
+	} else {
+		info.hasMore = info.nextCursor != ""
+	}
+
+	return entities, info, nil
 }
 
 // GetEntity retrieves a single entity by ID
-func (c *Client) GetEntity(entityType string, id int, fields []string) (Entity, error) {
-	token, err := c.GetAccessToken()
+func (c *Client) GetEntity(ctx context.Context, entityType string, id int, fields []string) (Entity, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	token, err := c.GetAccessToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Construct URL with fields parameter
-	url := fmt.Sprintf("%s/api/%s/entity/%s/%d", c.baseURL, c.apiVersion, entityType, id)
+	reqURL := fmt.Sprintf("%s/api/%s/entity/%s/%d", c.baseURL, c.apiVersion, entityType, id)
 	if len(fields) > 0 {
-		url += "?fields=" + strings.Join(fields, ",")
+		reqURL += "?fields=" + strings.Join(fields, ",")
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -167,8 +221,11 @@ func (c *Client) GetEntity(entityType string, id int, fields []string) (Entity,
 }
 
 // CreateEntity creates a new entity
-func (c *Client) CreateEntity(entityType string, data map[string]interface{}) (Entity, error) {
-	token, err := c.GetAccessToken()
+func (c *Client) CreateEntity(ctx context.Context, entityType string, data map[string]interface{}) (Entity, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	token, err := c.GetAccessToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
@@ -178,9 +235,9 @@ func (c *Client) CreateEntity(entityType string, data map[string]interface{}) (E
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/api/%s/entity/%s", c.baseURL, c.apiVersion, entityType)
+	reqURL := fmt.Sprintf("%s/api/%s/entity/%s", c.baseURL, c.apiVersion, entityType)
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyJSON))
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewBuffer(bodyJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -234,12 +291,83 @@ func (c *Client) CreateEntity(entityType string, data map[string]interface{}) (E
 	return entity, nil
 }
 
-func (c *Client) GetUserByLogin(login string) (Entity, error) {
+// UpdateEntity updates the given fields on an existing entity.
+func (c *Client) UpdateEntity(ctx context.Context, entityType string, id int, data map[string]interface{}) (Entity, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	token, err := c.GetAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	bodyJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/%s/entity/%s/%d", c.baseURL, c.apiVersion, entityType, id)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", reqURL, bytes.NewBuffer(bodyJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		Data struct {
+			ID            int                    `json:"id"`
+			Type          string                 `json:"type"`
+			Attributes    map[string]interface{} `json:"attributes"`
+			Relationships map[string]interface{} `json:"relationships"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Convert to Entity
+	entity := Entity{
+		"id":   result.Data.ID,
+		"type": result.Data.Type,
+	}
+	// Merge attributes
+	for k, v := range result.Data.Attributes {
+		entity[k] = v
+	}
+	// Merge relationships
+	for k, v := range result.Data.Relationships {
+		entity[k] = v
+	}
+
+	return entity, nil
+}
+
+func (c *Client) GetUserByLogin(ctx context.Context, login string) (Entity, error) {
 	filters := []interface{}{
 		[]interface{}{"login", "is", login},
 	}
 
-	users, err := c.FindEntities("human_users", filters, []string{"id", "name", "login", "email"})
+	users, err := c.FindEntities(ctx, "human_users", filters, []string{"id", "name", "login", "email"})
 	if err != nil {
 		return nil, err
 	}
@@ -251,12 +379,12 @@ func (c *Client) GetUserByLogin(login string) (Entity, error) {
 	return users[0], nil
 }
 
-func (c *Client) GetUserByName(name string) (Entity, error) {
+func (c *Client) GetUserByName(ctx context.Context, name string) (Entity, error) {
 	filters := []interface{}{
 		[]interface{}{"name", "is", name},
 	}
 
-	users, err := c.FindEntities("human_users", filters, []string{"id", "name", "login", "email"})
+	users, err := c.FindEntities(ctx, "human_users", filters, []string{"id", "name", "login", "email"})
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +396,7 @@ func (c *Client) GetUserByName(name string) (Entity, error) {
 	return users[0], nil
 }
 
-func (c *Client) GetShots(projectID int, fields []string) ([]Entity, error) {
+func (c *Client) GetShots(ctx context.Context, projectID int, fields []string) ([]Entity, error) {
 	var filters interface{}
 
 	if projectID > 0 {
@@ -284,10 +412,32 @@ func (c *Client) GetShots(projectID int, fields []string) ([]Entity, error) {
 		fields = []string{"code", "description", "sg_status_list"}
 	}
 
-	return c.FindEntities("shots", filters, fields)
+	return c.FindEntities(ctx, "shots", filters, fields)
 }
 
-func (c *Client) GetTasksForShot(shotID int, fields []string) ([]Entity, error) {
+// GetShotsIter is GetShots for result sets too large to hold in memory at
+// once: it returns an EntityIterator that pages through shots lazily
+// instead of fetching them all up front.
+func (c *Client) GetShotsIter(ctx context.Context, projectID int, opts IterateOptions) *EntityIterator {
+	var filters interface{}
+
+	if projectID > 0 {
+		filters = []interface{}{
+			[]interface{}{"project", "is", map[string]interface{}{
+				"type": "Project",
+				"id":   projectID,
+			}},
+		}
+	}
+
+	if len(opts.Fields) == 0 {
+		opts.Fields = []string{"code", "description", "sg_status_list"}
+	}
+
+	return c.Iterate(ctx, "shots", filters, opts)
+}
+
+func (c *Client) GetTasksForShot(ctx context.Context, shotID int, fields []string) ([]Entity, error) {
 	filters := []interface{}{
 		[]interface{}{"entity", "is", map[string]interface{}{
 			"type": "Shot",
@@ -299,10 +449,10 @@ func (c *Client) GetTasksForShot(shotID int, fields []string) ([]Entity, error)
 		fields = []string{"content", "sg_status_list", "task_assignees"}
 	}
 
-	return c.FindEntities("tasks", filters, fields)
+	return c.FindEntities(ctx, "tasks", filters, fields)
 }
 
-func (c *Client) GetTasksForUser(userID int, fields []string) ([]Entity, error) {
+func (c *Client) GetTasksForUser(ctx context.Context, userID int, fields []string) ([]Entity, error) {
 	filters := []interface{}{
 		[]interface{}{"task_assignees", "is", map[string]interface{}{
 			"type": "HumanUser",
@@ -314,10 +464,28 @@ func (c *Client) GetTasksForUser(userID int, fields []string) ([]Entity, error)
 		fields = []string{"content", "entity", "sg_status_list", "project"}
 	}
 
-	return c.FindEntities("tasks", filters, fields)
+	return c.FindEntities(ctx, "tasks", filters, fields)
 }
 
-func (c *Client) GetUserShotTasks(userID int, shotID int, fields []string) ([]Entity, error) {
+// GetTasksForUserIter is GetTasksForUser for result sets too large to hold
+// in memory at once: it returns an EntityIterator that pages through tasks
+// lazily instead of fetching them all up front.
+func (c *Client) GetTasksForUserIter(ctx context.Context, userID int, opts IterateOptions) *EntityIterator {
+	filters := []interface{}{
+		[]interface{}{"task_assignees", "is", map[string]interface{}{
+			"type": "HumanUser",
+			"id":   userID,
+		}},
+	}
+
+	if len(opts.Fields) == 0 {
+		opts.Fields = []string{"content", "entity", "sg_status_list", "project"}
+	}
+
+	return c.Iterate(ctx, "tasks", filters, opts)
+}
+
+func (c *Client) GetUserShotTasks(ctx context.Context, userID int, shotID int, fields []string) ([]Entity, error) {
 	filters := []interface{}{
 		[]interface{}{"entity", "is", map[string]interface{}{
 			"type": "Shot",
@@ -333,16 +501,23 @@ func (c *Client) GetUserShotTasks(userID int, shotID int, fields []string) ([]En
 		fields = []string{"content", "sg_status_list", "task_assignees"}
 	}
 
-	return c.FindEntities("tasks", filters, fields)
+	return c.FindEntities(ctx, "tasks", filters, fields)
 }
 
-func (c *Client) GetShotsForUser(userID int, fields []string) ([]Entity, error) {
+// GetShotsForUser returns the shots backing every task assigned to userID.
+// It fans out a task lookup followed by a shot lookup, both bound to ctx, so
+// the whole chain can be cancelled mid-flight by the caller.
+func (c *Client) GetShotsForUser(ctx context.Context, userID int, fields []string) ([]Entity, error) {
 	// First get all tasks for the user
-	tasks, err := c.GetTasksForUser(userID, []string{"entity"})
+	tasks, err := c.GetTasksForUser(ctx, userID, []string{"entity"})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Extract unique shot IDs
 	shotIDs := make(map[int]bool)
 	for _, task := range tasks {
@@ -381,12 +556,12 @@ func (c *Client) GetShotsForUser(userID int, fields []string) ([]Entity, error)
 		fields = []string{"code", "description", "sg_status_list", "project"}
 	}
 
-	return c.FindEntities("shots", filters, fields)
+	return c.FindEntities(ctx, "shots", filters, fields)
 }
 
 // NewClientFromEnv creates a new client using environment variables
 // It will automatically try to load a .env file from common locations
-func NewClientFromEnv() (*Client, error) {
+func NewClientFromEnv(ctx context.Context) (*Client, error) {
 	// Try to load .env file (silently fail if not found)
 	tryLoadEnv()
 
@@ -404,7 +579,7 @@ func NewClientFromEnv() (*Client, error) {
 		return nil, fmt.Errorf("FLOW_SCRIPT_KEY environment variable is required")
 	}
 
-	return NewClient(Config{
+	return NewClient(ctx, Config{
 		SiteURL:    siteURL,
 		ScriptName: scriptName,
 		ScriptKey:  scriptKey,
@@ -0,0 +1,143 @@
+// Package entity holds the runtime support shared by every generated
+// flowapi/gen/* package. cmd/flowapi-gen emits one package per Flow entity
+// type (gen/shot, gen/task, gen/humanuser, ...); none of that generated code
+// talks to the API directly, it all builds on the pieces here.
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jonaskluger/flow-api-go"
+)
+
+// Ref is a typed reference to another entity, as Flow represents it in a
+// relationship field (e.g. {"type": "Shot", "id": 123}). It decodes both the
+// flat form and the "data"-wrapped form FindEntities can return.
+type Ref struct {
+	Type string
+	ID   int
+}
+
+// UnmarshalJSON accepts both {"type":"Shot","id":1} and the
+// {"data":{"type":"Shot","id":1}} wrapper Flow sometimes uses for
+// relationship fields.
+func (r *Ref) UnmarshalJSON(data []byte) error {
+	var wrapped struct {
+		Data *struct {
+			Type string `json:"type"`
+			ID   int    `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && wrapped.Data != nil {
+		r.Type = wrapped.Data.Type
+		r.ID = wrapped.Data.ID
+		return nil
+	}
+
+	var flat struct {
+		Type string `json:"type"`
+		ID   int    `json:"id"`
+	}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return fmt.Errorf("decode entity ref: %w", err)
+	}
+	r.Type = flat.Type
+	r.ID = flat.ID
+	return nil
+}
+
+// Decode converts a generic flowapi.Entity into a typed struct by round
+// tripping it through JSON. It exists so generated field structs can stay
+// plain Go types instead of every caller doing map[string]interface{}
+// assertions by hand.
+func Decode(e flowapi.Entity, out interface{}) error {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal entity: %w", err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("decode entity: %w", err)
+	}
+	return nil
+}
+
+// DecodeAll converts a slice of generic entities into typed structs, as
+// returned by a Query's All method.
+func DecodeAll[T any](entities []flowapi.Entity) ([]*T, error) {
+	out := make([]*T, len(entities))
+	for i, e := range entities {
+		var v T
+		if err := Decode(e, &v); err != nil {
+			return nil, err
+		}
+		out[i] = &v
+	}
+	return out, nil
+}
+
+// Predicate is a single filter term in Flow's simple filter array syntax:
+// []interface{}{field, operator, value}. Generated packages expose typed
+// constructors (shot.ProjectEQ, shot.CodeContains, ...) that return these.
+type Predicate []interface{}
+
+// EQ builds an "is" predicate.
+func EQ(field string, value interface{}) Predicate {
+	return Predicate{field, "is", value}
+}
+
+// In builds an "in" predicate for membership checks.
+func In(field string, values interface{}) Predicate {
+	return Predicate{field, "in", values}
+}
+
+// Contains builds a "contains" predicate for free-text fields.
+func Contains(field string, value string) Predicate {
+	return Predicate{field, "contains", value}
+}
+
+// Between builds a "between" predicate, most commonly used for date fields.
+func Between(field string, low, high interface{}) Predicate {
+	return Predicate{field, "between", []interface{}{low, high}}
+}
+
+// Builder is the common fluent query shape every generated *Query type
+// wraps. It collects predicates and fields and defers to
+// flowapi.Client.FindEntities to execute.
+type Builder struct {
+	client     *flowapi.Client
+	entityType string
+	predicates []Predicate
+	fields     []string
+}
+
+// NewBuilder constructs the shared query state for a generated entity
+// package; it is called from each package's Query constructor, not by
+// end users directly.
+func NewBuilder(c *flowapi.Client, entityType string) *Builder {
+	return &Builder{client: c, entityType: entityType}
+}
+
+// Where appends predicates, ANDed together per Flow's default filter
+// combination behavior.
+func (b *Builder) Where(predicates ...Predicate) *Builder {
+	b.predicates = append(b.predicates, predicates...)
+	return b
+}
+
+// Fields restricts the response to the given field names.
+func (b *Builder) Fields(fields ...string) *Builder {
+	b.fields = fields
+	return b
+}
+
+// All executes the query and returns the raw entities; generated packages
+// wrap this with DecodeAll to return typed structs.
+func (b *Builder) All(ctx context.Context) ([]flowapi.Entity, error) {
+	filters := make([]interface{}, len(b.predicates))
+	for i, p := range b.predicates {
+		filters[i] = []interface{}(p)
+	}
+	return b.client.FindEntities(ctx, b.entityType, filters, b.fields)
+}
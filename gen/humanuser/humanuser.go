@@ -0,0 +1,73 @@
+// Hand-written in the layout cmd/flowapi-gen emits for the HumanUser schema;
+// it is not generator output.
+
+package humanuser
+
+import (
+	"context"
+
+	"github.com/jonaskluger/flow-api-go"
+	"github.com/jonaskluger/flow-api-go/gen/entity"
+)
+
+// entityType is the Flow entity type this package wraps.
+const entityType = "human_users"
+
+// Field name constants, matching the schema field names exactly so they can
+// be passed straight through to FindEntities' fields parameter.
+const (
+	FieldID    = "id"
+	FieldName  = "name"
+	FieldLogin = "login"
+	FieldEmail = "email"
+)
+
+// HumanUser is the typed representation of a HumanUser entity.
+type HumanUser struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+// LoginEQ matches the user with the exact given login.
+func LoginEQ(login string) entity.Predicate {
+	return entity.EQ(FieldLogin, login)
+}
+
+// NameEQ matches the user with the exact given name.
+func NameEQ(name string) entity.Predicate {
+	return entity.EQ(FieldName, name)
+}
+
+// HumanUserQuery builds a fluent query over human users.
+type HumanUserQuery struct {
+	b *entity.Builder
+}
+
+// Query starts a fluent query over human users, e.g.
+// humanuser.Query(c).Where(humanuser.LoginEQ(login)).All(ctx).
+func Query(c *flowapi.Client) *HumanUserQuery {
+	return &HumanUserQuery{b: entity.NewBuilder(c, entityType)}
+}
+
+// Where appends predicates to the query, ANDed together.
+func (q *HumanUserQuery) Where(predicates ...entity.Predicate) *HumanUserQuery {
+	q.b.Where(predicates...)
+	return q
+}
+
+// Fields restricts the response to the given field names.
+func (q *HumanUserQuery) Fields(fields ...string) *HumanUserQuery {
+	q.b.Fields(fields...)
+	return q
+}
+
+// All executes the query and decodes the results into typed HumanUsers.
+func (q *HumanUserQuery) All(ctx context.Context) ([]*HumanUser, error) {
+	raw, err := q.b.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entity.DecodeAll[HumanUser](raw)
+}
@@ -0,0 +1,98 @@
+// Hand-written in the layout cmd/flowapi-gen emits for the Shot schema; it is
+// not generator output.
+
+package shot
+
+import (
+	"context"
+
+	"github.com/jonaskluger/flow-api-go"
+	"github.com/jonaskluger/flow-api-go/gen/entity"
+)
+
+// entityType is the Flow entity type this package wraps.
+const entityType = "shots"
+
+// Field name constants, matching the schema field names exactly so they can
+// be passed straight through to FindEntities' fields parameter.
+const (
+	FieldID          = "id"
+	FieldCode        = "code"
+	FieldDescription = "description"
+	FieldStatus      = "sg_status_list"
+	FieldProject     = "project"
+)
+
+// Shot is the typed representation of a Shot entity.
+type Shot struct {
+	ID          int         `json:"id"`
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Status      string      `json:"sg_status_list"`
+	Project     *entity.Ref `json:"project,omitempty"`
+}
+
+// ProjectEQ matches shots belonging to the given project ID.
+func ProjectEQ(projectID int) entity.Predicate {
+	return entity.EQ(FieldProject, map[string]interface{}{"type": "Project", "id": projectID})
+}
+
+// CodeEQ matches shots with the exact given code.
+func CodeEQ(code string) entity.Predicate {
+	return entity.EQ(FieldCode, code)
+}
+
+// CodeContains matches shots whose code contains the given substring.
+func CodeContains(substr string) entity.Predicate {
+	return entity.Contains(FieldCode, substr)
+}
+
+// StatusIn matches shots whose status is one of the given values.
+func StatusIn(statuses ...string) entity.Predicate {
+	values := make([]interface{}, len(statuses))
+	for i, s := range statuses {
+		values[i] = s
+	}
+	return entity.In(FieldStatus, values)
+}
+
+// IDIn matches shots whose ID is one of the given values.
+func IDIn(ids ...int) entity.Predicate {
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return entity.In(FieldID, values)
+}
+
+// ShotQuery builds a fluent query over shots.
+type ShotQuery struct {
+	b *entity.Builder
+}
+
+// Query starts a fluent query over shots, e.g.
+// shot.Query(c).Where(shot.ProjectEQ(pid)).Fields(shot.FieldCode).All(ctx).
+func Query(c *flowapi.Client) *ShotQuery {
+	return &ShotQuery{b: entity.NewBuilder(c, entityType)}
+}
+
+// Where appends predicates to the query, ANDed together.
+func (q *ShotQuery) Where(predicates ...entity.Predicate) *ShotQuery {
+	q.b.Where(predicates...)
+	return q
+}
+
+// Fields restricts the response to the given field names.
+func (q *ShotQuery) Fields(fields ...string) *ShotQuery {
+	q.b.Fields(fields...)
+	return q
+}
+
+// All executes the query and decodes the results into typed Shots.
+func (q *ShotQuery) All(ctx context.Context) ([]*Shot, error) {
+	raw, err := q.b.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entity.DecodeAll[Shot](raw)
+}
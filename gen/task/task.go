@@ -0,0 +1,86 @@
+// Hand-written in the layout cmd/flowapi-gen emits for the Task schema; it is
+// not generator output.
+
+package task
+
+import (
+	"context"
+
+	"github.com/jonaskluger/flow-api-go"
+	"github.com/jonaskluger/flow-api-go/gen/entity"
+)
+
+// entityType is the Flow entity type this package wraps.
+const entityType = "tasks"
+
+// Field name constants, matching the schema field names exactly so they can
+// be passed straight through to FindEntities' fields parameter.
+const (
+	FieldID        = "id"
+	FieldContent   = "content"
+	FieldStatus    = "sg_status_list"
+	FieldEntity    = "entity"
+	FieldProject   = "project"
+	FieldAssignees = "task_assignees"
+)
+
+// Task is the typed representation of a Task entity.
+type Task struct {
+	ID        int          `json:"id"`
+	Content   string       `json:"content"`
+	Status    string       `json:"sg_status_list"`
+	Entity    *entity.Ref  `json:"entity,omitempty"`
+	Project   *entity.Ref  `json:"project,omitempty"`
+	Assignees []entity.Ref `json:"task_assignees,omitempty"`
+}
+
+// ShotEQ matches tasks linked to the given Shot ID.
+func ShotEQ(shotID int) entity.Predicate {
+	return entity.EQ(FieldEntity, map[string]interface{}{"type": "Shot", "id": shotID})
+}
+
+// AssigneeEQ matches tasks assigned to the given HumanUser ID.
+func AssigneeEQ(userID int) entity.Predicate {
+	return entity.EQ(FieldAssignees, map[string]interface{}{"type": "HumanUser", "id": userID})
+}
+
+// StatusIn matches tasks whose status is one of the given values.
+func StatusIn(statuses ...string) entity.Predicate {
+	values := make([]interface{}, len(statuses))
+	for i, s := range statuses {
+		values[i] = s
+	}
+	return entity.In(FieldStatus, values)
+}
+
+// TaskQuery builds a fluent query over tasks.
+type TaskQuery struct {
+	b *entity.Builder
+}
+
+// Query starts a fluent query over tasks, e.g.
+// task.Query(c).Where(task.ShotEQ(shotID)).Fields(task.FieldContent).All(ctx).
+func Query(c *flowapi.Client) *TaskQuery {
+	return &TaskQuery{b: entity.NewBuilder(c, entityType)}
+}
+
+// Where appends predicates to the query, ANDed together.
+func (q *TaskQuery) Where(predicates ...entity.Predicate) *TaskQuery {
+	q.b.Where(predicates...)
+	return q
+}
+
+// Fields restricts the response to the given field names.
+func (q *TaskQuery) Fields(fields ...string) *TaskQuery {
+	q.b.Fields(fields...)
+	return q
+}
+
+// All executes the query and decodes the results into typed Tasks.
+func (q *TaskQuery) All(ctx context.Context) ([]*Task, error) {
+	raw, err := q.b.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entity.DecodeAll[Task](raw)
+}
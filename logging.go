@@ -0,0 +1,50 @@
+package flowapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger returns a Middleware that emits a structured log record for
+// every request/response pair, tagged with a generated request_id so
+// a request and its eventual response (or the retries in between) can be
+// correlated in the log stream.
+func WithLogger(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requestID := newRequestID()
+			start := time.Now()
+
+			logger.Info("flowapi request",
+				"request_id", requestID,
+				"method", req.Method,
+				"url", req.URL.String(),
+			)
+
+			resp, err := next.RoundTrip(req)
+
+			attrs := []any{
+				"request_id", requestID,
+				"duration", time.Since(start),
+			}
+			if err != nil {
+				logger.Error("flowapi request failed", append(attrs, "error", err)...)
+				return resp, err
+			}
+
+			logger.Info("flowapi response", append(attrs, "status", resp.StatusCode)...)
+			return resp, nil
+		})
+	}
+}
+
+// newRequestID generates a short random hex id for correlating log lines
+// belonging to the same request across retries.
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
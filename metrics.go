@@ -0,0 +1,69 @@
+package flowapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WithMetrics returns a Middleware that records request counts and
+// latencies per entity_type and response status, registered against reg.
+// The entity_type label is parsed out of the request path (the segment
+// after "/entity/"); requests that don't match the entity API shape (the
+// auth endpoint, schema endpoint, ...) are labeled "other".
+func WithMetrics(reg prometheus.Registerer) Middleware {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "flowapi_requests_total",
+		Help: "Total Flow API requests made, by entity type and status.",
+	}, []string{"entity_type", "status"})
+
+	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "flowapi_request_duration_seconds",
+		Help:    "Flow API request duration, by entity type.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"entity_type"})
+
+	reg.MustRegister(requests, durations)
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			entityType := entityTypeFromPath(req.URL.Path)
+			start := time.Now()
+
+			resp, err := next.RoundTrip(req)
+
+			durations.WithLabelValues(entityType).Observe(time.Since(start).Seconds())
+
+			status := "error"
+			if err == nil {
+				status = strconv.Itoa(resp.StatusCode)
+			}
+			requests.WithLabelValues(entityType, status).Inc()
+
+			return resp, err
+		})
+	}
+}
+
+// entityTypeFromPath extracts the entity_type segment from paths shaped
+// like /api/v1.1/entity/{entity_type}/... , falling back to "other" for
+// auth and schema requests. Event log polls go through
+// /entity/event_log_entries/ and are labeled "event_log_entries".
+func entityTypeFromPath(path string) string {
+	const marker = "/entity/"
+	idx := strings.Index(path, marker)
+	if idx == -1 {
+		return "other"
+	}
+	rest := path[idx+len(marker):]
+	if i := strings.IndexByte(rest, '/'); i != -1 {
+		rest = rest[:i]
+	}
+	if rest == "" {
+		return "other"
+	}
+	return rest
+}
@@ -0,0 +1,202 @@
+package flowapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// findOptions collects the pagination and sort knobs FindEntities accepts
+// via FindOption.
+type findOptions struct {
+	pageSize   int
+	pageNumber int
+	cursor     string
+	sort       []string
+}
+
+// FindOption customizes a FindEntities (or Iterate) call's pagination and
+// sort behavior.
+type FindOption func(*findOptions)
+
+// WithPageSize sets page[size] on the request.
+func WithPageSize(size int) FindOption {
+	return func(o *findOptions) { o.pageSize = size }
+}
+
+// WithPageNumber sets page[number] on the request, for resuming offset
+// pagination at a specific page.
+func WithPageNumber(number int) FindOption {
+	return func(o *findOptions) { o.pageNumber = number }
+}
+
+// WithCursor switches to the site's page[cursor] pagination mode,
+// continuing from the given cursor. An empty cursor adds no page[cursor]
+// parameter, so that request is the same as one made without WithCursor.
+func WithCursor(cursor string) FindOption {
+	return func(o *findOptions) { o.cursor = cursor }
+}
+
+// WithSort sets the sort order, e.g. WithSort("code", "-created_at") for
+// ascending code then descending created_at, matching the Flow API's
+// sort=code,-created_at syntax.
+func WithSort(fields ...string) FindOption {
+	return func(o *findOptions) { o.sort = fields }
+}
+
+func resolveFindOptions(opts []FindOption) findOptions {
+	var o findOptions
+	for _, apply := range opts {
+		apply(&o)
+	}
+	return o
+}
+
+// pageInfo is the pagination bookkeeping findEntitiesPage hands back to
+// Client.Iterate; it isn't exposed to FindEntities callers.
+type pageInfo struct {
+	nextCursor string
+	hasMore    bool
+}
+
+// nextCursorFromLink extracts the page[cursor] value from a links.next URL
+// like the Flow API returns when cursor pagination is active.
+func nextCursorFromLink(link string) string {
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("page[cursor]")
+}
+
+// IterateOptions configures Client.Iterate.
+type IterateOptions struct {
+	// Fields restricts the response to the given field names.
+	Fields []string
+
+	// Sort sets the iteration order, e.g. []string{"code", "-created_at"}.
+	// Required for offset pagination to give stable page boundaries.
+	Sort []string
+
+	// PageSize is the number of entities fetched per underlying request in
+	// offset pagination. Defaults to 100. It is not sent when UseCursor is
+	// set; cursor-mode pages use the site's own page size.
+	PageSize int
+
+	// UseCursor switches to the API's page[cursor] pagination mode when
+	// the site supports it, instead of page[number] offset pagination.
+	UseCursor bool
+}
+
+const defaultIteratePageSize = 100
+
+// EntityIterator lazily pages through a FindEntities result set so callers
+// working with thousands of entities don't have to load them all into
+// memory at once.
+type EntityIterator struct {
+	client     *Client
+	ctx        context.Context
+	entityType string
+	filters    interface{}
+	opts       IterateOptions
+
+	buf       []Entity
+	bufIdx    int
+	pageNum   int
+	cursor    string
+	exhausted bool
+	current   Entity
+	err       error
+}
+
+// Iterate returns an EntityIterator over entities of entityType matching
+// filters. Call Next in a loop, reading Entity() after each true result,
+// then check Err() once Next returns false.
+func (c *Client) Iterate(ctx context.Context, entityType string, filters interface{}, opts IterateOptions) *EntityIterator {
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultIteratePageSize
+	}
+	return &EntityIterator{
+		client:     c,
+		ctx:        ctx,
+		entityType: entityType,
+		filters:    filters,
+		opts:       opts,
+		pageNum:    1,
+	}
+}
+
+// Next advances the iterator, fetching another page when the current one
+// is exhausted. It returns false when there are no more entities or an
+// error occurred; check Err() to distinguish the two.
+func (it *EntityIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.bufIdx >= len(it.buf) {
+		if it.exhausted {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.buf) == 0 {
+			return false
+		}
+	}
+
+	it.current = it.buf[it.bufIdx]
+	it.bufIdx++
+	return true
+}
+
+func (it *EntityIterator) fetchPage() error {
+	var fetchOpts []FindOption
+	if len(it.opts.Sort) > 0 {
+		fetchOpts = append(fetchOpts, WithSort(it.opts.Sort...))
+	}
+	if it.opts.UseCursor {
+		fetchOpts = append(fetchOpts, WithCursor(it.cursor))
+	} else {
+		fetchOpts = append(fetchOpts, WithPageSize(it.opts.PageSize), WithPageNumber(it.pageNum))
+	}
+
+	entities, info, err := it.client.findEntitiesPage(it.ctx, it.entityType, it.filters, it.opts.Fields, fetchOpts...)
+	if err != nil {
+		return fmt.Errorf("fetch page: %w", err)
+	}
+
+	it.buf = entities
+	it.bufIdx = 0
+	it.pageNum++
+	it.cursor = info.nextCursor
+	it.exhausted = !info.hasMore
+
+	return nil
+}
+
+// Entity returns the entity at the iterator's current position. Only
+// valid after a call to Next that returned true.
+func (it *EntityIterator) Entity() Entity {
+	return it.current
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *EntityIterator) Err() error {
+	return it.err
+}
+
+// Close releases the iterator's resources. It is safe to call multiple
+// times. The iterator currently holds no resources beyond its in-memory
+// buffer, but Close exists so a future change (e.g. an open cursor on the
+// server) doesn't require an API change.
+func (it *EntityIterator) Close() error {
+	it.buf = nil
+	it.exhausted = true
+	return nil
+}
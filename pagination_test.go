@@ -0,0 +1,98 @@
+package flowapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient returns a Client wired up against server, authenticated via
+// a client_credentials exchange the caller's handler must also serve at
+// /api/v1.1/auth/access_token.
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	client, err := NewClient(context.Background(), Config{
+		SiteURL:    server.URL,
+		ScriptName: "script",
+		ScriptKey:  "key",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func serveAuth(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, `{"access_token":"tok","token_type":"bearer","expires_in":3600,"refresh_token":"refresh"}`)
+}
+
+// TestEntityIteratorPagesUntilShortPage exercises Client.Iterate's offset
+// pagination: it should keep fetching pages while each is full and stop
+// once a short page signals there's nothing left.
+func TestEntityIteratorPagesUntilShortPage(t *testing.T) {
+	pages := [][]string{
+		{"1", "2"}, // full page: PageSize is 2, so hasMore is inferred true
+		{"3"},      // short page: hasMore is false, iteration stops
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1.1/auth/access_token":
+			serveAuth(w, r)
+		case "/api/v1.1/entity/widgets/_search":
+			page := r.URL.Query().Get("page[number]")
+			idx := 0
+			if page == "2" {
+				idx = 1
+			}
+			var data []string
+			for _, id := range pages[idx] {
+				data = append(data, fmt.Sprintf(`{"id":%s,"type":"Widget","attributes":{}}`, id))
+			}
+			fmt.Fprintf(w, `{"data":[%s]}`, joinJSON(data))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	it := client.Iterate(context.Background(), "widgets", nil, IterateOptions{
+		Sort:     []string{"id"},
+		PageSize: 2,
+	})
+	defer it.Close()
+
+	var ids []int
+	for it.Next() {
+		id, _ := it.Entity()["id"].(int)
+		ids = append(ids, id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterate: %v", err)
+	}
+
+	want := []int{1, 2, 3}
+	if len(ids) != len(want) {
+		t.Fatalf("got %d entities, want %d: %v", len(ids), len(want), ids)
+	}
+	for i, id := range ids {
+		if id != want[i] {
+			t.Errorf("entity %d: got id %d, want %d", i, id, want[i])
+		}
+	}
+}
+
+func joinJSON(parts []string) string {
+	out := ""
+	for i, p := range parts {
+		if i > 0 {
+			out += ","
+		}
+		out += p
+	}
+	return out
+}
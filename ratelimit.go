@@ -0,0 +1,25 @@
+package flowapi
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit returns a Middleware that throttles outgoing requests to
+// at most rps requests per second, allowing bursts of up to burst
+// requests. It blocks (respecting the request's context) rather than
+// rejecting requests outright, so callers don't need their own retry loop
+// just to stay under a site's rate limit.
+func WithRateLimit(rps float64, burst int) Middleware {
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
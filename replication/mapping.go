@@ -0,0 +1,123 @@
+package replication
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Mapping persists the source_id <-> target_id correspondence for each
+// entity type a Policy replicates, so relationship fields (a task's
+// "entity", a shot's "project") can be rewritten to point at the target
+// site's ids instead of the source site's.
+type Mapping interface {
+	// Get returns the target id mapped to sourceID for entityType, or
+	// ok == false if no mapping exists yet.
+	Get(entityType string, sourceID int) (targetID int, ok bool, err error)
+
+	// Set records that sourceID on the source site corresponds to
+	// targetID on the target site, for entityType.
+	Set(entityType string, sourceID, targetID int) error
+}
+
+// mappingKey identifies one entity-type/source-id pair within a Mapping
+// store.
+type mappingKey struct {
+	EntityType string
+	SourceID   int
+}
+
+// MemoryMapping is a Mapping backed by an in-process map. Mappings are
+// lost on restart; use FileMapping to persist them.
+type MemoryMapping struct {
+	mu    sync.Mutex
+	byKey map[mappingKey]int
+}
+
+// NewMemoryMapping returns an empty MemoryMapping.
+func NewMemoryMapping() *MemoryMapping {
+	return &MemoryMapping{byKey: make(map[mappingKey]int)}
+}
+
+// Get implements Mapping.
+func (m *MemoryMapping) Get(entityType string, sourceID int) (int, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	targetID, ok := m.byKey[mappingKey{entityType, sourceID}]
+	return targetID, ok, nil
+}
+
+// Set implements Mapping.
+func (m *MemoryMapping) Set(entityType string, sourceID, targetID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.byKey == nil {
+		m.byKey = make(map[mappingKey]int)
+	}
+	m.byKey[mappingKey{entityType, sourceID}] = targetID
+	return nil
+}
+
+// FileMapping is a Mapping backed by a single JSON file, suitable for a
+// daemon running replication policies across restarts without a database.
+type FileMapping struct {
+	path string
+	mu   sync.Mutex
+	data map[string]map[string]int // entityType -> sourceID (as string) -> targetID
+}
+
+// NewFileMapping loads (or initializes) a FileMapping backed by path.
+func NewFileMapping(path string) (*FileMapping, error) {
+	m := &FileMapping{path: path, data: make(map[string]map[string]int)}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read mapping file: %w", err)
+	}
+	if len(raw) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(raw, &m.data); err != nil {
+		return nil, fmt.Errorf("parse mapping file: %w", err)
+	}
+	return m, nil
+}
+
+// Get implements Mapping.
+func (m *FileMapping) Get(entityType string, sourceID int) (int, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	byID, ok := m.data[entityType]
+	if !ok {
+		return 0, false, nil
+	}
+	targetID, ok := byID[fmt.Sprint(sourceID)]
+	return targetID, ok, nil
+}
+
+// Set implements Mapping.
+func (m *FileMapping) Set(entityType string, sourceID, targetID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	byID, ok := m.data[entityType]
+	if !ok {
+		byID = make(map[string]int)
+		m.data[entityType] = byID
+	}
+	byID[fmt.Sprint(sourceID)] = targetID
+
+	raw, err := json.MarshalIndent(m.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal mapping file: %w", err)
+	}
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("write mapping file: %w", err)
+	}
+	return os.Rename(tmp, m.path)
+}
@@ -0,0 +1,339 @@
+// Package replication mirrors entities of a given type from one Flow site
+// to another on a schedule, preserving cross-entity relationships via a
+// persistent id mapping between the two sites.
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jonaskluger/flow-api-go"
+)
+
+// ConflictPolicy decides what happens when an entity already exists on the
+// target site.
+type ConflictPolicy string
+
+const (
+	// ConflictSkip leaves the existing target entity untouched.
+	ConflictSkip ConflictPolicy = "skip"
+
+	// ConflictOverwrite always replaces the target entity's fields with
+	// the source entity's.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+
+	// ConflictNewestWins compares updated_at on both sides and only
+	// writes if the source entity is newer.
+	ConflictNewestWins ConflictPolicy = "newest_wins"
+)
+
+// Policy describes one source-to-target replication job: which entity type
+// to mirror, how to filter and translate it, and how often to run.
+type Policy struct {
+	// Name identifies the policy in logs. The Mapping store is keyed by
+	// entity type and source id, not by policy.
+	Name string
+
+	// Source is the site entities are read from.
+	Source *flowapi.Client
+
+	// Target is the site entities are written to.
+	Target *flowapi.Client
+
+	// EntityType is the Flow entity type to replicate, e.g. "shots".
+	EntityType string
+
+	// Filters narrows which source entities are replicated, in
+	// FindEntities' filter array format.
+	Filters interface{}
+
+	// Fields lists the source fields to read and replicate, e.g.
+	// []string{"code", "description", "sg_status_list", "project"}. It
+	// must include every field ConflictNewestWins or a custom FieldMap
+	// depends on (e.g. "updated_at"); fields not listed here are never
+	// copied to the target.
+	Fields []string
+
+	// FieldMap renames fields between source and target, e.g. when the
+	// two sites use different custom field names for the same concept.
+	// Keys are source field names, values are target field names; fields
+	// not present default to passing through unchanged.
+	FieldMap map[string]string
+
+	// Cron is a standard 5-field cron expression controlling how often
+	// Scheduler runs this policy.
+	Cron string
+
+	// Enabled controls whether Scheduler runs this policy at all.
+	Enabled bool
+
+	// Conflict decides what happens when a mapped target entity already
+	// exists. Defaults to ConflictSkip.
+	Conflict ConflictPolicy
+
+	// DryRun skips every write to Target and to the Mapping store; Run still
+	// counts the creates and updates it would have made, and Scheduler logs
+	// those counts. Individual planned mutations are not logged.
+	DryRun bool
+
+	// LastRunAt is updated by Scheduler after each run.
+	LastRunAt time.Time
+}
+
+func (p *Policy) conflictPolicy() ConflictPolicy {
+	if p.Conflict == "" {
+		return ConflictSkip
+	}
+	return p.Conflict
+}
+
+// readOnlyFields are Flow-managed audit fields the API rejects as writable
+// on CreateEntity/UpdateEntity. They're dropped by translateFields even
+// when Policy.Fields requests them for the policy's own use (e.g.
+// "updated_at" for ConflictNewestWins).
+var readOnlyFields = map[string]bool{
+	"created_at": true,
+	"created_by": true,
+	"updated_at": true,
+	"updated_by": true,
+}
+
+// translateFields renames a source entity's fields per FieldMap, drops the
+// bookkeeping ("id", "type") and read-only audit fields that
+// CreateEntity/UpdateEntity reject as regular fields, and rewrites
+// relationship-valued fields (a shot's "project", a task's
+// "entity"/"task_assignees") through mapping so they point at the target
+// site's ids instead of the source site's.
+func (p *Policy) translateFields(mapping Mapping, source flowapi.Entity) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(source))
+	for k, v := range source {
+		if k == "id" || k == "type" || readOnlyFields[k] {
+			continue
+		}
+		targetField := k
+		if mapped, ok := p.FieldMap[k]; ok {
+			targetField = mapped
+		}
+		translated, err := translateRelationship(mapping, v)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", k, err)
+		}
+		out[targetField] = translated
+	}
+	return out, nil
+}
+
+// translateRelationship rewrites a field value shaped like a Flow
+// relationship ({"type":...,"id":...}, the {"data": ...}-wrapped form, or a
+// to-many list of either) so its id points at the target site's entity
+// instead of the source site's, per mapping. Values that aren't
+// relationship-shaped, and relationships mapping has no target id for yet,
+// pass through unchanged.
+func translateRelationship(mapping Mapping, v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, elem := range val {
+			translated, err := translateRelationship(mapping, elem)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = translated
+		}
+		return out, nil
+
+	case map[string]interface{}:
+		ref := val
+		if data, ok := val["data"].(map[string]interface{}); ok {
+			ref = data
+		}
+		refType, hasType := ref["type"].(string)
+		refID, hasID := asID(ref["id"])
+		if !hasType || !hasID {
+			return v, nil
+		}
+
+		targetID, mapped, err := mapping.Get(apiEntityType(refType), refID)
+		if err != nil {
+			return nil, fmt.Errorf("load mapping for %s %d: %w", refType, refID, err)
+		}
+		if !mapped {
+			return v, nil
+		}
+		return map[string]interface{}{"type": refType, "id": targetID}, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// apiEntityType converts a relationship ref's PascalCase singular type
+// (e.g. "HumanUser") to the snake_case plural entity type used as the Flow
+// API path segment and as Policy.EntityType/Mapping's key (e.g.
+// "human_users") — the inverse of cmd/flowapi-gen's goName.
+func apiEntityType(refType string) string {
+	var b strings.Builder
+	for i, r := range refType {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	b.WriteByte('s')
+	return b.String()
+}
+
+// Run pages through every source entity matching Filters and upserts it
+// into Target, translating ids via mapping to preserve relationships
+// across sites. It returns the number of entities created, updated, and
+// skipped.
+func (p *Policy) Run(ctx context.Context, mapping Mapping) (Result, error) {
+	var result Result
+
+	iter := p.Source.Iterate(ctx, p.EntityType, p.Filters, flowapi.IterateOptions{
+		Fields: p.Fields,
+		Sort:   []string{"id"},
+	})
+	defer iter.Close()
+
+	for iter.Next() {
+		source := iter.Entity()
+		sourceID, ok := entityID(source)
+		if !ok {
+			continue
+		}
+
+		outcome, err := p.replicateOne(ctx, mapping, source, sourceID)
+		if err != nil {
+			return result, fmt.Errorf("replicate %s %d: %w", p.EntityType, sourceID, err)
+		}
+		switch outcome {
+		case outcomeCreated:
+			result.Created++
+		case outcomeUpdated:
+			result.Updated++
+		case outcomeSkipped:
+			result.Skipped++
+		}
+	}
+
+	return result, iter.Err()
+}
+
+type outcome int
+
+const (
+	outcomeCreated outcome = iota
+	outcomeUpdated
+	outcomeSkipped
+)
+
+func (p *Policy) replicateOne(ctx context.Context, mapping Mapping, source flowapi.Entity, sourceID int) (outcome, error) {
+	fields, err := p.translateFields(mapping, source)
+	if err != nil {
+		return outcomeSkipped, fmt.Errorf("translate fields: %w", err)
+	}
+
+	targetID, mapped, err := mapping.Get(p.EntityType, sourceID)
+	if err != nil {
+		return outcomeSkipped, fmt.Errorf("load mapping: %w", err)
+	}
+
+	if !mapped {
+		if p.DryRun {
+			return outcomeCreated, nil
+		}
+		created, err := p.Target.CreateEntity(ctx, p.EntityType, fields)
+		if err != nil {
+			return outcomeSkipped, fmt.Errorf("create on target: %w", err)
+		}
+		newID, ok := entityID(created)
+		if !ok {
+			return outcomeSkipped, fmt.Errorf("target did not return an id for the created entity")
+		}
+		if err := mapping.Set(p.EntityType, sourceID, newID); err != nil {
+			return outcomeSkipped, fmt.Errorf("save mapping: %w", err)
+		}
+		return outcomeCreated, nil
+	}
+
+	switch p.conflictPolicy() {
+	case ConflictSkip:
+		return outcomeSkipped, nil
+
+	case ConflictNewestWins:
+		existing, err := p.Target.GetEntity(ctx, p.EntityType, targetID, []string{"updated_at"})
+		if err != nil {
+			return outcomeSkipped, fmt.Errorf("load target for comparison: %w", err)
+		}
+		if !sourceNewer(source, existing) {
+			return outcomeSkipped, nil
+		}
+		fallthrough
+
+	case ConflictOverwrite:
+		if p.DryRun {
+			return outcomeUpdated, nil
+		}
+		if _, err := p.Target.UpdateEntity(ctx, p.EntityType, targetID, fields); err != nil {
+			return outcomeSkipped, fmt.Errorf("update target: %w", err)
+		}
+		return outcomeUpdated, nil
+	}
+
+	return outcomeSkipped, nil
+}
+
+// sourceNewer reports whether source's updated_at is strictly after
+// target's. Entities missing the field are treated as not newer, so a
+// malformed timestamp never overwrites good data.
+func sourceNewer(source, target flowapi.Entity) bool {
+	s, ok := parseUpdatedAt(source)
+	if !ok {
+		return false
+	}
+	t, ok := parseUpdatedAt(target)
+	if !ok {
+		return true
+	}
+	return s.After(t)
+}
+
+func parseUpdatedAt(e flowapi.Entity) (time.Time, bool) {
+	v, ok := e["updated_at"].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func entityID(e flowapi.Entity) (int, bool) {
+	return asID(e["id"])
+}
+
+// asID converts a decoded JSON id value (int when built in-process, float64
+// when it came back through encoding/json) into an int.
+func asID(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// Result summarizes one Policy.Run.
+type Result struct {
+	Created int
+	Updated int
+	Skipped int
+}
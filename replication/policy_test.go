@@ -0,0 +1,162 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	flowapi "github.com/jonaskluger/flow-api-go"
+)
+
+// newTestTargetClient returns a *flowapi.Client authenticated against a
+// stub server that also serves handle for every non-auth request.
+func newTestTargetClient(t *testing.T, handle http.HandlerFunc) *flowapi.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1.1/auth/access_token" {
+			fmt.Fprint(w, `{"access_token":"tok","token_type":"bearer","expires_in":3600}`)
+			return
+		}
+		handle(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := flowapi.NewClient(context.Background(), flowapi.Config{
+		SiteURL:    server.URL,
+		ScriptName: "script",
+		ScriptKey:  "key",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestReplicateOneSkipsWhenAlreadyMappedByDefault(t *testing.T) {
+	target := newTestTargetClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to target: %s %s", r.Method, r.URL.Path)
+	})
+
+	mapping := NewMemoryMapping()
+	if err := mapping.Set("shots", 1, 100); err != nil {
+		t.Fatalf("seed mapping: %v", err)
+	}
+
+	p := &Policy{EntityType: "shots", Target: target} // Conflict unset defaults to ConflictSkip
+	got, err := p.replicateOne(context.Background(), mapping, flowapi.Entity{"id": 1, "code": "sh01"}, 1)
+	if err != nil {
+		t.Fatalf("replicateOne: %v", err)
+	}
+	if got != outcomeSkipped {
+		t.Fatalf("outcome = %v, want outcomeSkipped", got)
+	}
+}
+
+func TestReplicateOneCreatesWhenUnmapped(t *testing.T) {
+	target := newTestTargetClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1.1/entity/shots" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":100,"type":"Shot","attributes":{}}}`)
+	})
+
+	mapping := NewMemoryMapping()
+	p := &Policy{EntityType: "shots", Target: target}
+
+	got, err := p.replicateOne(context.Background(), mapping, flowapi.Entity{"id": 1, "code": "sh01"}, 1)
+	if err != nil {
+		t.Fatalf("replicateOne: %v", err)
+	}
+	if got != outcomeCreated {
+		t.Fatalf("outcome = %v, want outcomeCreated", got)
+	}
+
+	targetID, ok, err := mapping.Get("shots", 1)
+	if err != nil || !ok {
+		t.Fatalf("mapping.Get(shots, 1) = %d, %v, %v; want a mapping to have been saved", targetID, ok, err)
+	}
+	if targetID != 100 {
+		t.Errorf("mapped target id = %d, want 100", targetID)
+	}
+}
+
+func TestReplicateOneOverwritesMappedEntity(t *testing.T) {
+	target := newTestTargetClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1.1/entity/shots/100" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data":{"id":100,"type":"Shot","attributes":{}}}`)
+	})
+
+	mapping := NewMemoryMapping()
+	if err := mapping.Set("shots", 1, 100); err != nil {
+		t.Fatalf("seed mapping: %v", err)
+	}
+
+	p := &Policy{EntityType: "shots", Target: target, Conflict: ConflictOverwrite}
+	got, err := p.replicateOne(context.Background(), mapping, flowapi.Entity{"id": 1, "code": "sh01"}, 1)
+	if err != nil {
+		t.Fatalf("replicateOne: %v", err)
+	}
+	if got != outcomeUpdated {
+		t.Fatalf("outcome = %v, want outcomeUpdated", got)
+	}
+}
+
+func TestReplicateOneNewestWinsSkipsWhenSourceIsOlder(t *testing.T) {
+	target := newTestTargetClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1.1/entity/shots/100" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"data":{"id":100,"type":"Shot","attributes":{"updated_at":"2026-01-02T00:00:00Z"}}}`)
+	})
+
+	mapping := NewMemoryMapping()
+	if err := mapping.Set("shots", 1, 100); err != nil {
+		t.Fatalf("seed mapping: %v", err)
+	}
+
+	p := &Policy{EntityType: "shots", Target: target, Conflict: ConflictNewestWins}
+	source := flowapi.Entity{"id": 1, "code": "sh01", "updated_at": "2026-01-01T00:00:00Z"}
+
+	got, err := p.replicateOne(context.Background(), mapping, source, 1)
+	if err != nil {
+		t.Fatalf("replicateOne: %v", err)
+	}
+	if got != outcomeSkipped {
+		t.Fatalf("outcome = %v, want outcomeSkipped", got)
+	}
+}
+
+func TestReplicateOneNewestWinsUpdatesWhenSourceIsNewer(t *testing.T) {
+	target := newTestTargetClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1.1/entity/shots/100":
+			fmt.Fprint(w, `{"data":{"id":100,"type":"Shot","attributes":{"updated_at":"2026-01-01T00:00:00Z"}}}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1.1/entity/shots/100":
+			fmt.Fprint(w, `{"data":{"id":100,"type":"Shot","attributes":{}}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	mapping := NewMemoryMapping()
+	if err := mapping.Set("shots", 1, 100); err != nil {
+		t.Fatalf("seed mapping: %v", err)
+	}
+
+	p := &Policy{EntityType: "shots", Target: target, Conflict: ConflictNewestWins}
+	source := flowapi.Entity{"id": 1, "code": "sh01", "updated_at": "2026-01-02T00:00:00Z"}
+
+	got, err := p.replicateOne(context.Background(), mapping, source, 1)
+	if err != nil {
+		t.Fatalf("replicateOne: %v", err)
+	}
+	if got != outcomeUpdated {
+		t.Fatalf("outcome = %v, want outcomeUpdated", got)
+	}
+}
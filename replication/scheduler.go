@@ -0,0 +1,104 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Scheduler runs a set of enabled Policies on their configured cron
+// schedules, persisting cross-site id mappings to a shared Mapping store.
+type Scheduler struct {
+	mapping  Mapping
+	logger   *log.Logger
+	cron     *cron.Cron
+	mu       sync.Mutex
+	policies []*Policy
+}
+
+// NewScheduler returns a Scheduler that replicates through mapping,
+// logging to logger (os.Stderr's standard logger if nil).
+func NewScheduler(mapping Mapping, logger *log.Logger) *Scheduler {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Scheduler{
+		mapping: mapping,
+		logger:  logger,
+		cron:    cron.New(),
+	}
+}
+
+// Add registers a policy with the scheduler. Disabled policies are kept
+// (so they show up in Policies) but never scheduled.
+func (s *Scheduler) Add(p *Policy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policies = append(s.policies, p)
+	if !p.Enabled {
+		return nil
+	}
+
+	policy := p // capture for the closure below
+	_, err := s.cron.AddFunc(policy.Cron, func() {
+		s.runAndLog(context.Background(), policy)
+	})
+	if err != nil {
+		return fmt.Errorf("schedule policy %q: %w", policy.Name, err)
+	}
+	return nil
+}
+
+// Policies returns every policy registered with the scheduler, enabled or
+// not.
+func (s *Scheduler) Policies() []*Policy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*Policy, len(s.policies))
+	copy(out, s.policies)
+	return out
+}
+
+// RunOnce runs every enabled policy a single time, in registration order,
+// instead of waiting for their cron schedules. It stops at the first policy
+// that fails and returns its error; the policies after it are not run.
+// Useful for a one-shot CLI invocation.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	for _, p := range s.Policies() {
+		if !p.Enabled {
+			continue
+		}
+		if err := s.runAndLog(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start begins running scheduled policies as a daemon. It returns
+// immediately; call Stop to shut down.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop halts the scheduler, waiting for any in-flight run to complete.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+func (s *Scheduler) runAndLog(ctx context.Context, p *Policy) error {
+	result, err := p.Run(ctx, s.mapping)
+	p.LastRunAt = time.Now()
+	if err != nil {
+		s.logger.Printf("replication policy %q failed: %v", p.Name, err)
+		return err
+	}
+	s.logger.Printf("replication policy %q: created=%d updated=%d skipped=%d dry_run=%t",
+		p.Name, result.Created, result.Updated, result.Skipped, p.DryRun)
+	return nil
+}
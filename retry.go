@@ -0,0 +1,159 @@
+package flowapi
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures WithRetry's exponential backoff.
+type RetryPolicy struct {
+	// MaxRetries is the number of retry attempts after the initial
+	// request. Defaults to 3.
+	MaxRetries int
+
+	// BaseDelay is the starting backoff delay, doubled on each attempt
+	// and randomized with full jitter. Defaults to 200ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff delay. Defaults to 10s.
+	MaxDelay time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxRetries <= 0 {
+		p.MaxRetries = 3
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 200 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 10 * time.Second
+	}
+	return p
+}
+
+// WithRetry returns a Middleware that retries requests that fail with a
+// 429 or 5xx response, using exponential backoff with full jitter. It
+// honors a Retry-After header (seconds or HTTP date) when present, and
+// never retries a request whose body can't be re-sent (streaming bodies
+// without GetBody set).
+func WithRetry(policy RetryPolicy) Middleware {
+	policy = policy.withDefaults()
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var lastResp *http.Response
+			var lastErr error
+
+			for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+				if attempt > 0 {
+					delay := retryDelay(policy, attempt, lastResp)
+					timer := time.NewTimer(delay)
+					select {
+					case <-timer.C:
+					case <-req.Context().Done():
+						timer.Stop()
+						return nil, req.Context().Err()
+					}
+				}
+
+				attemptReq := req
+				if attempt > 0 {
+					r, err := rewindBody(req)
+					if err != nil {
+						return lastResp, lastErr
+					}
+					attemptReq = r
+				}
+
+				resp, err := next.RoundTrip(attemptReq)
+				if err != nil {
+					if req.Context().Err() != nil {
+						return nil, err
+					}
+					lastErr = err
+					lastResp = nil
+					continue
+				}
+
+				if !isRetryableStatus(resp.StatusCode) || attempt == policy.MaxRetries {
+					return resp, nil
+				}
+
+				// Drain and close so the connection can be reused before
+				// we retry.
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				lastResp = resp
+				lastErr = nil
+			}
+
+			return lastResp, lastErr
+		})
+	}
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// rewindBody clones req with its body reset via GetBody, which
+// http.NewRequestWithContext populates for in-memory bodies (the only kind
+// this client constructs). Requests built with a non-replayable body
+// cannot be retried.
+func rewindBody(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		if req.Body == nil || req.Body == http.NoBody {
+			clone := req.Clone(req.Context())
+			return clone, nil
+		}
+		return nil, errNotRewindable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+var errNotRewindable = &retryError{"request body cannot be replayed for retry"}
+
+type retryError struct{ msg string }
+
+func (e *retryError) Error() string { return e.msg }
+
+// retryDelay computes the exponential backoff delay for the given attempt,
+// with full jitter, honoring Retry-After on the previous response if set.
+func retryDelay(policy RetryPolicy, attempt int, lastResp *http.Response) time.Duration {
+	if lastResp != nil {
+		if d, ok := retryAfter(lastResp); ok {
+			return d
+		}
+	}
+
+	capped := float64(policy.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if capped > float64(policy.MaxDelay) {
+		capped = float64(policy.MaxDelay)
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
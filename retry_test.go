@@ -0,0 +1,74 @@
+package flowapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableStatus(t *testing.T) {
+	cases := map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusBadRequest:          false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+	}
+	for code, want := range cases {
+		if got := isRetryableStatus(code); got != want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestRetryDelayHonorsRetryAfterSeconds(t *testing.T) {
+	policy := RetryPolicy{}.withDefaults()
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+	got := retryDelay(policy, 1, resp)
+	if got != 2*time.Second {
+		t.Fatalf("retryDelay with Retry-After header = %v, want %v", got, 2*time.Second)
+	}
+}
+
+func TestRetryDelayCapsAtMaxDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}.withDefaults()
+
+	// At a high attempt number, the uncapped exponential backoff would
+	// far exceed MaxDelay; retryDelay must never return more than that.
+	for attempt := 1; attempt <= 10; attempt++ {
+		if got := retryDelay(policy, attempt, nil); got > policy.MaxDelay {
+			t.Fatalf("retryDelay(attempt=%d) = %v, want <= %v", attempt, got, policy.MaxDelay)
+		}
+	}
+}
+
+func TestRetryDelayGrowsWithAttempt(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Minute}.withDefaults()
+
+	// retryDelay jitters within [0, cap], so compare the caps rather than
+	// the random samples themselves, and check each sample stays within
+	// its attempt's cap.
+	var prevCap time.Duration
+	for attempt := 1; attempt <= 5; attempt++ {
+		cap := time.Duration(float64(policy.BaseDelay) * pow2(attempt-1))
+		if cap > policy.MaxDelay {
+			cap = policy.MaxDelay
+		}
+		if got := retryDelay(policy, attempt, nil); got > cap {
+			t.Fatalf("retryDelay(attempt=%d) = %v, want <= %v", attempt, got, cap)
+		}
+		if cap < prevCap {
+			t.Fatalf("backoff cap shrank at attempt %d: %v < %v", attempt, cap, prevCap)
+		}
+		prevCap = cap
+	}
+}
+
+func pow2(n int) float64 {
+	result := 1.0
+	for i := 0; i < n; i++ {
+		result *= 2
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package flowapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FieldSchema is the subset of Flow's field-schema response used by
+// cmd/flowapi-gen to pick a Go type and predicate set for each field.
+type FieldSchema struct {
+	DataType struct {
+		Value string `json:"value"`
+	} `json:"data_type"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// EntitySchema maps field name to its schema, as returned by
+// /api/v1.1/schema/{entity_type}/fields.
+type EntitySchema map[string]FieldSchema
+
+// GetEntitySchema fetches the field schema for entityType. It exists mainly
+// to back cmd/flowapi-gen, which treats the live schema as the source of
+// truth for the typed packages under flowapi/gen.
+func (c *Client) GetEntitySchema(ctx context.Context, entityType string) (EntitySchema, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	token, err := c.GetAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/%s/schema/%s/fields", c.baseURL, c.apiVersion, entityType)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("schema request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var schema EntitySchema
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema response: %w", err)
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,192 @@
+package flowapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// SSEHandler returns an http.Handler that fans a single Client.Subscribe
+// stream out to any number of HTTP clients over Server-Sent Events, so a
+// subscription can be consumed with e.g. `curl -N` instead of importing
+// this package. All connections share one upstream poll loop against Flow,
+// run by an internal hub, instead of each request starting its own; the
+// hub only polls while at least one SSE client is connected, and starts a
+// fresh poll for each new wave of subscribers.
+//
+// The returned stop func cancels ctx's child and tears down any in-flight
+// poll, and must be called once the handler is no longer mounted (e.g. on
+// shutdown, or before discarding it in a test) to avoid leaking the poll
+// goroutine.
+func SSEHandler(ctx context.Context, client *Client, opts SubscribeOptions) (http.Handler, func()) {
+	hubCtx, cancel := context.WithCancel(ctx)
+	hub := newSSEHub(hubCtx, client, opts)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		reqCtx := r.Context()
+		entries, errs := hub.subscribe()
+		defer hub.unsubscribe(entries)
+
+		for {
+			select {
+			case entry, ok := <-entries:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(entry)
+				if err != nil {
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+					flusher.Flush()
+					continue
+				}
+				fmt.Fprintf(w, "id: %d\nevent: event_log_entry\ndata: %s\n\n", entry.ID, payload)
+				flusher.Flush()
+
+			case err, ok := <-errs:
+				if !ok {
+					return
+				}
+				if err == nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+				flusher.Flush()
+
+			case <-reqCtx.Done():
+				return
+			}
+		}
+	})
+
+	return handler, cancel
+}
+
+// sseHub runs Client.Subscribe on demand and fans its entries and errors
+// out to every registered subscriber, so N concurrent SSE clients behind
+// one SSEHandler share one poll loop against Flow instead of each
+// multiplying it. It only polls while it has at least one subscriber.
+type sseHub struct {
+	ctx    context.Context
+	client *Client
+	opts   SubscribeOptions
+
+	mu     sync.Mutex
+	subs   map[chan EventLogEntry]chan error
+	cancel context.CancelFunc // non-nil while a poll is in flight
+}
+
+// newSSEHub returns a hub ready to register subscribers against client with
+// opts. No upstream subscription is started until the first subscriber
+// connects; ctx bounds the hub's entire lifetime, independent of any single
+// HTTP connection's.
+func newSSEHub(ctx context.Context, client *Client, opts SubscribeOptions) *sseHub {
+	return &sseHub{
+		ctx:    ctx,
+		client: client,
+		opts:   opts,
+		subs:   make(map[chan EventLogEntry]chan error),
+	}
+}
+
+// subscribe registers a new per-connection subscriber and returns the
+// channels it will receive every subsequent entry and error on, starting
+// the upstream poll if this is the first subscriber.
+func (h *sseHub) subscribe() (chan EventLogEntry, chan error) {
+	entries := make(chan EventLogEntry, 16)
+	errs := make(chan error, 1)
+
+	h.mu.Lock()
+	h.subs[entries] = errs
+	if h.cancel == nil {
+		h.start()
+	}
+	h.mu.Unlock()
+
+	return entries, errs
+}
+
+// start begins one upstream poll and fans its output to every subscriber
+// until either the hub's context is cancelled or unsubscribe stops it for
+// lack of subscribers. Callers must hold h.mu.
+func (h *sseHub) start() {
+	pollCtx, cancel := context.WithCancel(h.ctx)
+	h.cancel = cancel
+
+	entries, errs := h.client.Subscribe(pollCtx, h.opts)
+	go func() {
+		for entries != nil || errs != nil {
+			select {
+			case entry, ok := <-entries:
+				if !ok {
+					entries = nil
+					continue
+				}
+				h.broadcast(entry)
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				h.broadcastErr(err)
+			}
+		}
+	}()
+}
+
+// unsubscribe removes and closes a subscriber previously returned by
+// subscribe, stopping the upstream poll once no subscribers remain.
+func (h *sseHub) unsubscribe(entries chan EventLogEntry) {
+	h.mu.Lock()
+	errs, ok := h.subs[entries]
+	delete(h.subs, entries)
+	if len(h.subs) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+	h.mu.Unlock()
+
+	if ok {
+		close(entries)
+		close(errs)
+	}
+}
+
+// broadcast fans entry out to every subscriber, dropping it for any
+// subscriber whose buffer is full rather than blocking the whole hub on one
+// slow SSE connection.
+func (h *sseHub) broadcast(entry EventLogEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for entries := range h.subs {
+		select {
+		case entries <- entry:
+		default:
+		}
+	}
+}
+
+// broadcastErr is broadcast for the upstream subscription's error channel.
+func (h *sseHub) broadcastErr(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, errs := range h.subs {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package flowapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EventLogEntry is Flow's canonical change-feed entity. Every create,
+// update, and delete in the system is recorded as one of these, in
+// strictly increasing id order, which is what makes it safe to resume a
+// subscription from the last id seen.
+type EventLogEntry struct {
+	ID         int       `json:"id"`
+	EventType  string    `json:"event_type"`
+	EntityType string    `json:"entity_type"`
+	EntityID   int       `json:"entity_id"`
+	Entity     *Ref      `json:"entity"`
+	Project    *Ref      `json:"project"`
+	Meta       Entity    `json:"meta"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Ref is a minimal typed reference to another entity, used for fields like
+// EventLogEntry.Project where only the type/id pair matters.
+type Ref struct {
+	Type string `json:"type"`
+	ID   int    `json:"id"`
+}
+
+// parseRef decodes a relationship field value off a raw Entity into a Ref,
+// accepting both the flat {"type":...,"id":...} form and the {"data": ...}
+// wrapper Flow sometimes uses. It returns nil if v isn't relationship-shaped.
+func parseRef(v interface{}) *Ref {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if data, ok := m["data"].(map[string]interface{}); ok {
+		m = data
+	}
+	t, ok := m["type"].(string)
+	if !ok {
+		return nil
+	}
+	id, ok := m["id"].(float64)
+	if !ok {
+		return nil
+	}
+	return &Ref{Type: t, ID: int(id)}
+}
+
+// SubscribeOptions configures a Subscribe call.
+type SubscribeOptions struct {
+	// Filter narrows which event log entries are streamed.
+	Filter Filter
+
+	// CursorStore persists the last-seen event id so a subscription can
+	// resume after a restart. Defaults to a fresh MemoryCursorStore,
+	// which does not survive process restarts.
+	CursorStore CursorStore
+
+	// CursorKey namespaces the cursor within CursorStore, so multiple
+	// subscriptions can share one store. Defaults to "default".
+	CursorKey string
+
+	// PollInterval is how often to poll for new entries. Defaults to 5s.
+	PollInterval time.Duration
+
+	// BatchSize is the page size requested per poll. Defaults to 100.
+	BatchSize int
+}
+
+// Filter narrows an event log subscription by event type, entity type, and
+// project. Zero values mean "no filter on this dimension".
+type Filter struct {
+	EventType  string
+	EntityType string
+	ProjectID  int
+}
+
+func (f Filter) toFindFilters() []interface{} {
+	var filters []interface{}
+	if f.EventType != "" {
+		filters = append(filters, []interface{}{"event_type", "is", f.EventType})
+	}
+	if f.EntityType != "" {
+		filters = append(filters, []interface{}{"entity_type", "is", f.EntityType})
+	}
+	if f.ProjectID != 0 {
+		filters = append(filters, []interface{}{"project", "is", map[string]interface{}{
+			"type": "Project",
+			"id":   f.ProjectID,
+		}})
+	}
+	return filters
+}
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 100
+	minBackoff          = time.Second
+	maxBackoff          = time.Minute
+)
+
+// CursorStore persists the last-seen EventLogEntry id for a subscription so
+// it can resume where it left off after a restart.
+type CursorStore interface {
+	// Load returns the last saved cursor, or ok == false if none has been
+	// saved yet.
+	Load(key string) (cursor int, ok bool, err error)
+
+	// Save persists cursor for key.
+	Save(key string, cursor int) error
+}
+
+// MemoryCursorStore is a CursorStore backed by an in-process map. It is the
+// default when SubscribeOptions.CursorStore is unset; cursors are lost on
+// restart.
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]int
+}
+
+// NewMemoryCursorStore returns an empty MemoryCursorStore.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: make(map[string]int)}
+}
+
+// Load implements CursorStore.
+func (s *MemoryCursorStore) Load(key string) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursor, ok := s.cursors[key]
+	return cursor, ok, nil
+}
+
+// Save implements CursorStore.
+func (s *MemoryCursorStore) Save(key string, cursor int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cursors == nil {
+		s.cursors = make(map[string]int)
+	}
+	s.cursors[key] = cursor
+	return nil
+}
+
+// FileCursorStore is a CursorStore that persists one cursor per key as a
+// plain text file named "<dir>/<key>.cursor", so subscriptions survive
+// process restarts without needing a database.
+type FileCursorStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileCursorStore returns a FileCursorStore rooted at dir, creating it
+// if it doesn't already exist.
+func NewFileCursorStore(dir string) (*FileCursorStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cursor store dir: %w", err)
+	}
+	return &FileCursorStore{dir: dir}, nil
+}
+
+func (s *FileCursorStore) path(key string) string {
+	return s.dir + "/" + key + ".cursor"
+}
+
+// Load implements CursorStore.
+func (s *FileCursorStore) Load(key string) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read cursor file: %w", err)
+	}
+
+	cursor, err := strconv.Atoi(string(data))
+	if err != nil {
+		return 0, false, fmt.Errorf("parse cursor file: %w", err)
+	}
+	return cursor, true, nil
+}
+
+// Save implements CursorStore.
+func (s *FileCursorStore) Save(key string, cursor int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp := s.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(cursor)), 0o644); err != nil {
+		return fmt.Errorf("write cursor file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path(key)); err != nil {
+		return fmt.Errorf("rename cursor file: %w", err)
+	}
+	return nil
+}
+
+// Subscribe polls Flow's event_log_entries for new entries and streams
+// them on the returned channel. The cursor (last-seen event id) is
+// persisted via opts.CursorStore after every successful poll, so a process
+// restart resumes instead of replaying or dropping entries. Both returned
+// channels are closed once ctx is cancelled; callers should drain both in
+// a select loop.
+func (c *Client) Subscribe(ctx context.Context, opts SubscribeOptions) (<-chan EventLogEntry, <-chan error) {
+	store := opts.CursorStore
+	if store == nil {
+		store = NewMemoryCursorStore()
+	}
+	key := opts.CursorKey
+	if key == "" {
+		key = "default"
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	entries := make(chan EventLogEntry)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(entries)
+		defer close(errs)
+
+		cursor, _, err := store.Load(key)
+		if err != nil {
+			select {
+			case errs <- fmt.Errorf("load cursor: %w", err):
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		backoff := minBackoff
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			batch, err := c.pollEventLog(ctx, opts.Filter, cursor, batchSize)
+			if err != nil {
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+				backoff *= 2
+				if backoff > maxBackoff {
+					backoff = maxBackoff
+				}
+			} else {
+				backoff = minBackoff
+				for _, entry := range batch {
+					// De-duplicate against cursor gaps: never emit an
+					// entry we've already advanced past.
+					if entry.ID <= cursor {
+						continue
+					}
+					select {
+					case entries <- entry:
+					case <-ctx.Done():
+						return
+					}
+					cursor = entry.ID
+				}
+				if len(batch) > 0 {
+					if err := store.Save(key, cursor); err != nil {
+						select {
+						case errs <- fmt.Errorf("save cursor: %w", err):
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return entries, errs
+}
+
+// pollEventLog fetches up to batchSize EventLogEntry rows with id greater
+// than afterID, ordered by id ascending, matching filter.
+func (c *Client) pollEventLog(ctx context.Context, filter Filter, afterID, batchSize int) ([]EventLogEntry, error) {
+	filters := append([]interface{}{
+		[]interface{}{"id", "greater_than", afterID},
+	}, filter.toFindFilters()...)
+
+	fields := []string{"event_type", "entity_type", "entity", "project", "meta", "created_at"}
+
+	raw, err := c.FindEntities(ctx, "event_log_entries", filters, fields, WithPageSize(batchSize), WithSort("id"))
+	if err != nil {
+		return nil, fmt.Errorf("poll event log: %w", err)
+	}
+
+	entries := make([]EventLogEntry, 0, len(raw))
+	for _, e := range raw {
+		entry := EventLogEntry{}
+		if id, ok := e["id"].(int); ok {
+			entry.ID = id
+		} else if id, ok := e["id"].(float64); ok {
+			entry.ID = int(id)
+		}
+		if v, ok := e["event_type"].(string); ok {
+			entry.EventType = v
+		}
+		if v, ok := e["entity_type"].(string); ok {
+			entry.EntityType = v
+		}
+		if ref := parseRef(e["entity"]); ref != nil {
+			entry.Entity = ref
+			entry.EntityID = ref.ID
+		}
+		entry.Project = parseRef(e["project"])
+		if v, ok := e["created_at"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				entry.CreatedAt = t
+			}
+		}
+		if v, ok := e["meta"].(map[string]interface{}); ok {
+			entry.Meta = Entity(v)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,153 @@
+package flowapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Token is the pair of tokens and expiry issued by an auth grant, as
+// persisted by a TokenStore.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// TokenStore persists a Client's tokens so they survive process restarts
+// and can be shared across processes. The default is an in-memory store,
+// which doesn't survive a restart; use a FileTokenStore to do so.
+type TokenStore interface {
+	// Load returns the stored token, or ok == false if none has been
+	// saved yet.
+	Load() (token Token, ok bool, err error)
+
+	// Save persists token, replacing whatever was stored before.
+	Save(token Token) error
+
+	// Clear removes any stored token, e.g. after Client.Revoke.
+	Clear() error
+}
+
+// MemoryTokenStore is a TokenStore backed by a field on the struct itself.
+// It is the default when Config.TokenStore is unset.
+type MemoryTokenStore struct {
+	token Token
+	set   bool
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load implements TokenStore.
+func (s *MemoryTokenStore) Load() (Token, bool, error) {
+	return s.token, s.set, nil
+}
+
+// Save implements TokenStore.
+func (s *MemoryTokenStore) Save(token Token) error {
+	s.token = token
+	s.set = true
+	return nil
+}
+
+// Clear implements TokenStore.
+func (s *MemoryTokenStore) Clear() error {
+	s.token = Token{}
+	s.set = false
+	return nil
+}
+
+// FileTokenStore is a TokenStore backed by a JSON file, guarded by a
+// lockfile so multiple processes sharing the same script credentials don't
+// clobber each other's refreshed tokens.
+type FileTokenStore struct {
+	path string
+}
+
+// NewFileTokenStore returns a FileTokenStore that persists tokens to path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load() (Token, bool, error) {
+	var token Token
+	err := s.withLock(func() error {
+		data, err := os.ReadFile(s.path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read token file: %w", err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		return json.Unmarshal(data, &token)
+	})
+	if err != nil {
+		return Token{}, false, err
+	}
+	return token, token.AccessToken != "", nil
+}
+
+// Save implements TokenStore.
+func (s *FileTokenStore) Save(token Token) error {
+	return s.withLock(func() error {
+		data, err := json.Marshal(token)
+		if err != nil {
+			return fmt.Errorf("marshal token: %w", err)
+		}
+		tmp := s.path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o600); err != nil {
+			return fmt.Errorf("write token file: %w", err)
+		}
+		return os.Rename(tmp, s.path)
+	})
+}
+
+// Clear implements TokenStore.
+func (s *FileTokenStore) Clear() error {
+	return s.withLock(func() error {
+		err := os.Remove(s.path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// lockStaleAfter bounds how long a FileTokenStore lockfile is honored
+// before it's considered abandoned (e.g. the owning process crashed) and
+// taken over.
+const lockStaleAfter = 5 * time.Second
+
+// withLock takes the store's lockfile for the duration of fn, so
+// concurrent processes sharing a token file serialize their reads/writes.
+func (s *FileTokenStore) withLock(fn func() error) error {
+	lockPath := s.path + ".lock"
+
+	for {
+		lf, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			lf.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("create token lockfile: %w", err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > lockStaleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer os.Remove(lockPath)
+
+	return fn()
+}
@@ -0,0 +1,30 @@
+package flowapi
+
+import "net/http"
+
+// Middleware wraps a RoundTripper with additional behavior, composing the
+// same way net/http handlers do: each middleware receives the next
+// RoundTripper in the chain and decides whether, and how, to call it. This
+// is the seam Config.Middlewares hangs off of, letting callers add retry,
+// rate limiting, logging, and metrics (or their own) without the Client
+// hard-coding any of it.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// chain applies middlewares around base in order, so the first middleware
+// in the slice is outermost: it sees the request first and the response
+// last.
+func chain(base http.RoundTripper, middlewares []Middleware) http.RoundTripper {
+	rt := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper, mirroring
+// http.HandlerFunc.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}